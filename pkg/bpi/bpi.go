@@ -6,6 +6,11 @@ import (
 	"github.com/Yuelioi/bilibili/pkg/client"
 	"github.com/Yuelioi/bilibili/pkg/endpoints/article"
 	"github.com/Yuelioi/bilibili/pkg/endpoints/audio"
+	"github.com/Yuelioi/bilibili/pkg/endpoints/comment"
+	"github.com/Yuelioi/bilibili/pkg/endpoints/danmuku"
+	"github.com/Yuelioi/bilibili/pkg/endpoints/dynamic"
+	"github.com/Yuelioi/bilibili/pkg/endpoints/fav"
+	"github.com/Yuelioi/bilibili/pkg/endpoints/subtitle"
 	"github.com/Yuelioi/bilibili/pkg/endpoints/video"
 	"github.com/go-resty/resty/v2"
 )
@@ -13,13 +18,23 @@ import (
 type BpiService struct {
 	Client *client.Client
 
-	articleOnce sync.Once
-	audioOnce   sync.Once
-	videoOnce   sync.Once
+	articleOnce  sync.Once
+	audioOnce    sync.Once
+	videoOnce    sync.Once
+	subtitleOnce sync.Once
+	danmukuOnce  sync.Once
+	favOnce      sync.Once
+	commentOnce  sync.Once
+	dynamicOnce  sync.Once
 
-	article *article.Article
-	audio   *audio.Audio
-	video   *video.Video
+	article  *article.Article
+	audio    *audio.Audio
+	video    *video.Video
+	subtitle *subtitle.Subtitle
+	danmuku  *danmuku.Danmuku
+	fav      *fav.Fav
+	comment  *comment.Comment
+	dynamic  *dynamic.Dynamic
 }
 
 func New() *BpiService {
@@ -49,3 +64,38 @@ func (s *BpiService) Video() *video.Video {
 	})
 	return s.video
 }
+
+func (s *BpiService) Subtitle() *subtitle.Subtitle {
+	s.subtitleOnce.Do(func() {
+		s.subtitle = subtitle.New(s.Client)
+	})
+	return s.subtitle
+}
+
+func (s *BpiService) Danmuku() *danmuku.Danmuku {
+	s.danmukuOnce.Do(func() {
+		s.danmuku = danmuku.New(s.Client)
+	})
+	return s.danmuku
+}
+
+func (s *BpiService) Fav() *fav.Fav {
+	s.favOnce.Do(func() {
+		s.fav = fav.New(s.Client)
+	})
+	return s.fav
+}
+
+func (s *BpiService) Comment() *comment.Comment {
+	s.commentOnce.Do(func() {
+		s.comment = comment.New(s.Client)
+	})
+	return s.comment
+}
+
+func (s *BpiService) Dynamic() *dynamic.Dynamic {
+	s.dynamicOnce.Do(func() {
+		s.dynamic = dynamic.New(s.Client)
+	})
+	return s.dynamic
+}
@@ -1,10 +1,12 @@
 package article
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+	"github.com/Yuelioi/bilibili/pkg/misc"
 )
 
 // 获取用户专栏文章列表
@@ -51,6 +53,27 @@ func (a *Article) ArticleList(mid, pn, ps int, sort string) (*ListResponse, erro
 	return resp.Result().(*ListResponse), nil
 }
 
+// 遍历用户的全部专栏文章
+//
+// 参数：
+//   - ctx (context.Context): 用于取消遍历
+//   - mid (int): 用户uid
+//   - pageSize (int): 每页项数, 最大为30
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+//   - sort (string): publish_time：最新发布 view：最多阅读 fav：最多收藏
+func (a *Article) ArticleListIter(ctx context.Context, mid int, pageSize, maxItems int, sort string) *misc.Pager[ListArticle] {
+	return misc.NewPager(ctx, pageSize, 30, maxItems, func(pn, ps int) ([]ListArticle, int, error) {
+		resp, err := a.ArticleList(mid, pn, ps, sort)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Articles, resp.Data.Count, nil
+	})
+}
+
 // 获取用户专栏文集列表
 //
 // 参数：
@@ -1,8 +1,11 @@
 package audio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
 )
 
 // 查询自己创建的歌单
@@ -139,6 +142,63 @@ func (a *Audio) HotRank(pn, ps int) (*HotRankResponse, error) {
 	return resp.Result().(*HotRankResponse), nil
 }
 
+// 遍历自己创建的全部歌单
+//
+// 参数：
+//   - ctx (context.Context): 用于取消遍历
+//   - pageSize (int): 每页项数, 最大为100
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (a *Audio) CreatedCollectionsIter(ctx context.Context, pageSize, maxItems int) *misc.Pager[CollectionItemObj] {
+	return misc.NewPager(ctx, pageSize, 100, maxItems, func(pn, ps int) ([]CollectionItemObj, int, error) {
+		resp, err := a.CreatedCollections(pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 || resp.Data == nil {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Msg}
+		}
+		return resp.Data.Data, resp.Data.TotalSize, nil
+	})
+}
+
+// 遍历全部热门歌单
+//
+// 参数：
+//   - ctx (context.Context): 用于取消遍历
+//   - pageSize (int): 每页项数, 最大为100
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (a *Audio) HotPlaylistsIter(ctx context.Context, pageSize, maxItems int) *misc.Pager[PlaylistInfo] {
+	return misc.NewPager(ctx, pageSize, 100, maxItems, func(pn, ps int) ([]PlaylistInfo, int, error) {
+		resp, err := a.HotPlaylists(pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 || resp.Data == nil {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Msg}
+		}
+		return resp.Data.Playlists, resp.Data.TotalSize, nil
+	})
+}
+
+// 遍历全部热门榜单
+//
+// 参数：
+//   - ctx (context.Context): 用于取消遍历
+//   - pageSize (int): 每页项数, 最大为100
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (a *Audio) HotRankIter(ctx context.Context, pageSize, maxItems int) *misc.Pager[RankInfo] {
+	return misc.NewPager(ctx, pageSize, 100, maxItems, func(pn, ps int) ([]RankInfo, int, error) {
+		resp, err := a.HotRank(pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 || resp.Data == nil {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Msg}
+		}
+		return resp.Data.Playlists, resp.Data.TotalSize, nil
+	})
+}
+
 // -----------------------
 
 // CreatedCollectionsResponse represents the structure of the API response for querying created collections.
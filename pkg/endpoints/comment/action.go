@@ -1 +1,271 @@
 package comment
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 举报原因
+const (
+	ReportOther         = 0  // 其他, 需填写 content
+	ReportSpam          = 1  // 垃圾广告
+	ReportPorn          = 2  // 色情
+	ReportFlood         = 3  // 刷屏
+	ReportProvoke       = 4  // 引战
+	ReportSpoiler       = 5  // 剧透
+	ReportPolitics      = 6  // 政治
+	ReportAbuse         = 7  // 人身攻击
+	ReportIrrelevant    = 8  // 内容不相关
+	ReportIllegal       = 9  // 违法违规
+	ReportVulgar        = 10 // 低俗
+	ReportIllegalSite   = 11 // 非法网站
+	ReportFraud         = 12 // 赌博诈骗
+	ReportRumor         = 13 // 传播不实信息
+	ReportInstigate     = 14 // 怂恿教唆信息
+	ReportPrivacy       = 15 // 侵犯隐私
+	ReportFloorSnatch   = 16 // 抢楼
+	ReportHarmfulToTeen = 17 // 青少年不良信息
+)
+
+// 发表评论
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - root (int64): 根评论 rpid, 发表一级评论时为0
+//   - parent (int64): 回复的评论 rpid, 回复一级评论时与 root 相同, 发表一级评论时为0
+//   - message (string): 评论内容, 最多1000字符, 表情使用 "[doge]" 形式
+//   - pictures ([]Picture): 评论图片 (可选), 需先上传到B站图床
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (c *Comment) Add(oid, typ int, root, parent int64, message string, pictures []Picture) (*AddResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/reply/add"
+
+	formData := map[string]string{
+		"oid":     fmt.Sprintf("%d", oid),
+		"type":    fmt.Sprintf("%d", typ),
+		"message": message,
+		"plat":    "1",
+		"csrf":    c.client.CSRF,
+	}
+	if root != 0 {
+		formData["root"] = fmt.Sprintf("%d", root)
+		formData["parent"] = fmt.Sprintf("%d", parent)
+	}
+	if len(pictures) > 0 {
+		b, err := json.Marshal(pictures)
+		if err != nil {
+			return nil, err
+		}
+		formData["pictures"] = string(b)
+	}
+
+	resp, err := c.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: c.client.SESSDATA,
+		}).
+		SetResult(&AddResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*AddResponse), nil
+}
+
+// 回复评论, 见 Add
+//
+// Parameters:
+//   - to (Reply): 被回复的评论
+//   - message (string): 回复内容
+//   - pictures ([]Picture): 回复图片 (可选)
+func (c *Comment) ReplyTo(to Reply, message string, pictures []Picture) (*AddResponse, error) {
+	root := to.Root
+	if root == 0 {
+		root = to.Rpid
+	}
+	return c.Add(to.Oid, to.Type, root, to.Rpid, message, pictures)
+}
+
+// 点赞评论
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - rpid (int64): 目标评论 rpid
+//   - like (bool): true: 点赞, false: 取消点赞
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (c *Comment) Like(oid, typ int, rpid int64, like bool) (*misc.BaseResponse, error) {
+	return c.post("https://api.bilibili.com/x/v2/reply/action", map[string]string{
+		"oid":    fmt.Sprintf("%d", oid),
+		"type":   fmt.Sprintf("%d", typ),
+		"rpid":   fmt.Sprintf("%d", rpid),
+		"action": boolAction(like),
+	})
+}
+
+// 点踩评论
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - rpid (int64): 目标评论 rpid
+//   - hate (bool): true: 点踩, false: 取消点踩
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (c *Comment) Hate(oid, typ int, rpid int64, hate bool) (*misc.BaseResponse, error) {
+	return c.post("https://api.bilibili.com/x/v2/reply/hate", map[string]string{
+		"oid":    fmt.Sprintf("%d", oid),
+		"type":   fmt.Sprintf("%d", typ),
+		"rpid":   fmt.Sprintf("%d", rpid),
+		"action": boolAction(hate),
+	})
+}
+
+// 删除评论
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - rpid (int64): 目标评论 rpid
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+//
+// 备注：
+//   - 只能删除自己的评论, 或自己评论区下的评论
+func (c *Comment) Delete(oid, typ int, rpid int64) (*misc.BaseResponse, error) {
+	return c.post("https://api.bilibili.com/x/v2/reply/del", map[string]string{
+		"oid":  fmt.Sprintf("%d", oid),
+		"type": fmt.Sprintf("%d", typ),
+		"rpid": fmt.Sprintf("%d", rpid),
+	})
+}
+
+// 置顶评论
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - rpid (int64): 目标评论 rpid, 只能为一级评论
+//   - top (bool): true: 置顶, false: 取消置顶
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+//   - 需要为评论区 UP 主
+func (c *Comment) Top(oid, typ int, rpid int64, top bool) (*misc.BaseResponse, error) {
+	return c.post("https://api.bilibili.com/x/v2/reply/top", map[string]string{
+		"oid":    fmt.Sprintf("%d", oid),
+		"type":   fmt.Sprintf("%d", typ),
+		"rpid":   fmt.Sprintf("%d", rpid),
+		"action": boolAction(top),
+	})
+}
+
+// 举报评论
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - rpid (int64): 目标评论 rpid
+//   - reason (int): 举报原因, 见 ReportSpam 等
+//   - content (string): 举报详情, reason 为 ReportOther 时必要
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (c *Comment) Report(oid, typ int, rpid int64, reason int, content string) (*misc.BaseResponse, error) {
+	formData := map[string]string{
+		"oid":    fmt.Sprintf("%d", oid),
+		"type":   fmt.Sprintf("%d", typ),
+		"rpid":   fmt.Sprintf("%d", rpid),
+		"reason": fmt.Sprintf("%d", reason),
+	}
+	if content != "" {
+		formData["content"] = content
+	}
+	return c.post("https://api.bilibili.com/x/v2/reply/report", formData)
+}
+
+// 拉黑用户, 被拉黑的用户无法在自己的评论区发表评论
+//
+// Parameters:
+//   - mid (int): 目标用户 mid
+//   - block (bool): true: 拉黑, false: 取消拉黑
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+//
+// 备注：
+//   - 同时会解除关注关系, 作用于全部评论区
+func (c *Comment) Block(mid int, block bool) (*misc.BaseResponse, error) {
+	act := "6"
+	if block {
+		act = "5"
+	}
+	return c.post("https://api.bilibili.com/x/relation/modify", map[string]string{
+		"fid":    fmt.Sprintf("%d", mid),
+		"act":    act,
+		"re_src": "11",
+	})
+}
+
+func boolAction(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (c *Comment) post(baseURL string, formData map[string]string) (*misc.BaseResponse, error) {
+	formData["csrf"] = c.client.CSRF
+
+	resp, err := c.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: c.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
+
+// AddResponse 发表评论结果
+type AddResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -102表示账号被封停, -111表示csrf校验失败, -400表示请求错误, -404表示无此项, -509表示请求过于频繁, 12001表示已经存在评论主题, 12002表示评论区已关闭, 12009表示评论主体的type不合法, 12015表示需要评论验证码, 12016表示评论内容包含敏感信息, 12025表示评论字数过多, 12035表示该账号被UP主列入评论黑名单, 12051表示重复评论, 12045表示购买后才能发表评论
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		SuccessAction int              `json:"success_action"` // 作用尚不明确
+		SuccessToast  string           `json:"success_toast"`  // 成功提示, 如 "发送成功"
+		NeedCaptcha   bool             `json:"need_captcha"`   // 是否需要验证码
+		URL           string           `json:"url"`            // 验证码地址
+		Rpid          int64            `json:"rpid"`           // 评论 rpid
+		RpidStr       string           `json:"rpid_str"`       // 评论 rpid 字符串形式
+		Dialog        int64            `json:"dialog"`         // 所在对话的 rpid
+		DialogStr     string           `json:"dialog_str"`     // 所在对话的 rpid 字符串形式
+		Root          int64            `json:"root"`           // 根评论 rpid
+		RootStr       string           `json:"root_str"`       // 根评论 rpid 字符串形式
+		Parent        int64            `json:"parent"`         // 回复的评论 rpid
+		ParentStr     string           `json:"parent_str"`     // 回复的评论 rpid 字符串形式
+		Emote         map[string]Emote `json:"emote"`          // 使用的表情
+		Reply         Reply            `json:"reply"`          // 评论详情
+	} `json:"data"` // 数据本体
+}
@@ -0,0 +1,85 @@
+package comment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRecordComment() (*Comment, *tests.RecordTransport) {
+	c, rt := tests.NewRecordClient()
+	return New(c), rt
+}
+
+func TestAddForm(t *testing.T) {
+	c, rt := newRecordComment()
+
+	_, err := c.Add(100, TypeVideo, 0, 0, "一级评论", nil)
+	assert.NoError(t, err)
+	form := rt.Forms[0]
+	assert.Equal(t, "https://api.bilibili.com/x/v2/reply/add", rt.Requests[0].URL.String())
+	assert.Equal(t, "100", form.Get("oid"))
+	assert.Equal(t, "1", form.Get("type"))
+	assert.Equal(t, "一级评论", form.Get("message"))
+	assert.Equal(t, "token", form.Get("csrf"))
+	assert.NotContains(t, form, "root")
+	assert.NotContains(t, form, "parent")
+	assert.NotContains(t, form, "pictures")
+	cookie, err := rt.Requests[0].Cookie("SESSDATA")
+	assert.NoError(t, err)
+	assert.Equal(t, "sess", cookie.Value)
+
+	pics := []Picture{{ImgSrc: "https://i0.hdslb.com/a.png", ImgWidth: 10, ImgHeight: 20, ImgSize: 1.5}}
+	_, err = c.Add(100, TypeVideo, 0, 0, "带图", pics)
+	assert.NoError(t, err)
+	var got []Picture
+	assert.NoError(t, json.Unmarshal([]byte(rt.Forms[1].Get("pictures")), &got))
+	assert.Equal(t, pics, got)
+	assert.JSONEq(t, `[{"img_src":"https://i0.hdslb.com/a.png","img_width":10,"img_height":20,"img_size":1.5}]`, rt.Forms[1].Get("pictures"))
+}
+
+func TestReplyToForm(t *testing.T) {
+	c, rt := newRecordComment()
+
+	// 回复一级评论: root 与 parent 均为该评论
+	_, err := c.ReplyTo(Reply{Rpid: 10, Oid: 100, Type: TypeArticle}, "回复", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", rt.Forms[0].Get("root"))
+	assert.Equal(t, "10", rt.Forms[0].Get("parent"))
+	assert.Equal(t, "12", rt.Forms[0].Get("type"))
+
+	// 回复二级评论: root 为所在的一级评论
+	_, err = c.ReplyTo(Reply{Rpid: 20, Oid: 100, Type: TypeArticle, Root: 10, Parent: 10}, "楼中楼", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", rt.Forms[1].Get("root"))
+	assert.Equal(t, "20", rt.Forms[1].Get("parent"))
+}
+
+func TestActionForms(t *testing.T) {
+	c, rt := newRecordComment()
+
+	_, err := c.Block(7, true)
+	assert.NoError(t, err)
+	_, err = c.Block(7, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.bilibili.com/x/relation/modify", rt.Requests[0].URL.String())
+	assert.Equal(t, "5", rt.Forms[0].Get("act"))
+	assert.Equal(t, "6", rt.Forms[1].Get("act"))
+	assert.Equal(t, "7", rt.Forms[0].Get("fid"))
+	assert.Equal(t, "token", rt.Forms[0].Get("csrf"))
+
+	_, err = c.Like(100, TypeVideo, 10, true)
+	assert.NoError(t, err)
+	_, err = c.Top(100, TypeVideo, 10, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", rt.Forms[2].Get("action"))
+	assert.Equal(t, "0", rt.Forms[3].Get("action"))
+	assert.Equal(t, "https://api.bilibili.com/x/v2/reply/top", rt.Requests[3].URL.String())
+
+	_, err = c.Report(100, TypeVideo, 10, ReportOther, "理由")
+	assert.NoError(t, err)
+	assert.Equal(t, "0", rt.Forms[4].Get("reason"))
+	assert.Equal(t, "理由", rt.Forms[4].Get("content"))
+}
@@ -0,0 +1,11 @@
+package comment
+
+import "github.com/Yuelioi/bilibili/pkg/client"
+
+type Comment struct {
+	client *client.Client
+}
+
+func New(client *client.Client) *Comment {
+	return &Comment{client}
+}
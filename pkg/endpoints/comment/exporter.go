@@ -0,0 +1,471 @@
+package comment
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 导出格式
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// ExportRow 导出的一条评论
+type ExportRow struct {
+	Rpid     int64      `json:"rpid"`     // 评论 rpid
+	Oid      int        `json:"oid"`      // 评论区 id
+	Type     int        `json:"type"`     // 评论区类型
+	Root     int64      `json:"root"`     // 根评论 rpid, 一级评论为0
+	Parent   int64      `json:"parent"`   // 回复的评论 rpid, 一级评论为0
+	Mid      int        `json:"mid"`      // 发送者 mid
+	Uname    string     `json:"uname"`    // 发送者昵称
+	Level    int        `json:"level"`    // 发送者等级
+	Message  string     `json:"message"`  // 评论正文
+	Like     int        `json:"like"`     // 点赞数
+	Rcount   int        `json:"rcount"`   // 回复数
+	Ctime    int64      `json:"ctime"`    // 发送时间
+	Location string     `json:"location"` // IP 属地
+	Emotes   []RowEmote `json:"emotes"`   // 使用的表情, 按在正文中出现的顺序
+	At       []AtMember `json:"at"`       // 被 @ 的用户
+	Pictures []string   `json:"pictures"` // 图片 url
+}
+
+// RowEmote 导出行中的表情
+type RowEmote struct {
+	ID   int    `json:"id"`   // 表情 id
+	Text string `json:"text"` // 表情文本, 如 "[doge]"
+	URL  string `json:"url"`  // 表情图片 url
+}
+
+// CSV 表头, 与 ExportRow.CSV 对应; 列表字段以 ";" 分隔
+var CSVHeader = []string{
+	"rpid", "oid", "type", "root", "parent", "mid", "uname", "level", "message", "like", "rcount", "ctime", "location",
+	"emote_texts", "emote_ids", "at_mids", "at_names", "pictures",
+}
+
+// 将评论转换为导出行
+func NewExportRow(r Reply) ExportRow {
+	row := ExportRow{
+		Rpid:     r.Rpid,
+		Oid:      r.Oid,
+		Type:     r.Type,
+		Root:     r.Root,
+		Parent:   r.Parent,
+		Mid:      r.Mid,
+		Uname:    r.Member.Uname,
+		Level:    r.Member.LevelInfo.CurrentLevel,
+		Message:  r.Content.Message,
+		Like:     r.Like,
+		Rcount:   r.Rcount,
+		Ctime:    r.Ctime,
+		Location: r.Location(),
+		Emotes:   []RowEmote{},
+		At:       []AtMember{},
+		Pictures: []string{},
+	}
+	for text, e := range r.Content.Emote {
+		row.Emotes = append(row.Emotes, RowEmote{ID: e.ID, Text: text, URL: e.URL})
+	}
+	msg := r.Content.Message
+	sort.Slice(row.Emotes, func(i, j int) bool {
+		a, b := strings.Index(msg, row.Emotes[i].Text), strings.Index(msg, row.Emotes[j].Text)
+		if a != b {
+			return a < b
+		}
+		return row.Emotes[i].Text < row.Emotes[j].Text
+	})
+	row.At = append(row.At, r.Content.Members...)
+	for _, p := range r.Content.Pictures {
+		row.Pictures = append(row.Pictures, p.ImgSrc)
+	}
+	return row
+}
+
+// CSV 返回与 CSVHeader 对应的一行
+func (r ExportRow) CSV() []string {
+	var texts, ids, mids, names []string
+	for _, e := range r.Emotes {
+		texts = append(texts, e.Text)
+		ids = append(ids, strconv.Itoa(e.ID))
+	}
+	for _, a := range r.At {
+		mids = append(mids, a.Mid)
+		names = append(names, a.Uname)
+	}
+	return []string{
+		strconv.FormatInt(r.Rpid, 10),
+		strconv.Itoa(r.Oid),
+		strconv.Itoa(r.Type),
+		strconv.FormatInt(r.Root, 10),
+		strconv.FormatInt(r.Parent, 10),
+		strconv.Itoa(r.Mid),
+		r.Uname,
+		strconv.Itoa(r.Level),
+		r.Message,
+		strconv.Itoa(r.Like),
+		strconv.Itoa(r.Rcount),
+		strconv.FormatInt(r.Ctime, 10),
+		r.Location,
+		strings.Join(texts, ";"),
+		strings.Join(ids, ";"),
+		strings.Join(mids, ";"),
+		strings.Join(names, ";"),
+		strings.Join(r.Pictures, ";"),
+	}
+}
+
+// Exporter 导出评论区的全部评论 (含全部回复)
+//
+// 备注：
+//   - 每导出一页回复即保存断点, 中断后重新运行会从断点继续并追加写入
+//   - 写入与保存断点之间被中断时, 最多会重复写入一页
+//   - 风控/限流错误按 Retries 次指数退避重试
+type Exporter struct {
+	Oid        int           // 目标评论区 id
+	Type       int           // 评论区类型, 见 TypeVideo 等
+	Mode       int           // 排序方式, 见 ModeHot 等, 默认为 ModeTime
+	Output     string        // 输出文件路径
+	Format     string        // 输出格式, 见 FormatJSONL 等, 默认为 FormatJSONL
+	Checkpoint string        // 断点文件路径, 默认为 Output + ".checkpoint"
+	Interval   time.Duration // 两次请求的最小间隔, 默认为1秒
+	Retries    int           // 单次请求最多尝试次数, 默认为3
+	RetryWait  time.Duration // 首次重试前的等待时间, 默认为30秒
+
+	OnRow func(row ExportRow) // 每写入一行后回调 (可选)
+
+	main     func(offset string) (top, replies []Reply, next string, end bool, err error)
+	replies  func(root int64, pn int) ([]Reply, int, error)
+	throttle *misc.Throttle
+	state    exportState
+}
+
+// 断点内容
+type exportState struct {
+	Offset    string  `json:"offset"`     // 当前一级评论页的游标
+	Done      []int64 `json:"done"`       // 当前页已导出完毕的一级评论
+	Root      int64   `json:"root"`       // 正在导出回复的一级评论
+	RootPages int     `json:"root_pages"` // 该评论已导出的回复页数
+	Rows      int     `json:"rows"`       // 累计写入行数
+	Finished  bool    `json:"finished"`   // 是否已全部导出
+}
+
+// 创建评论导出任务
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - output (string): 输出文件路径, 以 .csv 结尾时使用 CSV 格式
+func (c *Comment) NewExporter(oid, typ int, output string) *Exporter {
+	e := &Exporter{
+		Oid:       oid,
+		Type:      typ,
+		Mode:      ModeTime,
+		Output:    output,
+		Format:    FormatJSONL,
+		Interval:  time.Second,
+		Retries:   3,
+		RetryWait: 30 * time.Second,
+	}
+	if strings.HasSuffix(output, ".csv") {
+		e.Format = FormatCSV
+	}
+	e.main = func(offset string) ([]Reply, []Reply, string, bool, error) {
+		resp, err := c.Main(e.Oid, e.Type, e.Mode, offset)
+		if err != nil {
+			return nil, nil, "", false, err
+		}
+		if resp.Code != 0 {
+			return nil, nil, "", false, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		cur := resp.Data.Cursor
+		return resp.Data.TopReplies, resp.Data.Replies, cur.PaginationReply.NextOffset, cur.IsEnd, nil
+	}
+	e.replies = func(root int64, pn int) ([]Reply, int, error) {
+		resp, err := c.Replies(e.Oid, e.Type, root, pn, 20)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Replies, resp.Data.Page.Count, nil
+	}
+	return e
+}
+
+// Run 开始导出, 返回本次写入的行数
+func (e *Exporter) Run(ctx context.Context) (int, error) {
+	e.throttle = &misc.Throttle{Interval: e.Interval}
+	if e.Checkpoint == "" {
+		e.Checkpoint = e.Output + ".checkpoint"
+	}
+	if err := e.load(); err != nil {
+		return 0, err
+	}
+	if e.state.Finished {
+		return 0, nil
+	}
+
+	w, err := e.open()
+	if err != nil {
+		return 0, err
+	}
+	defer w.Close()
+
+	written := 0
+	write := func(replies []Reply) error {
+		for _, r := range replies {
+			row := NewExportRow(r)
+			if err := w.Write(row); err != nil {
+				return err
+			}
+			written++
+			e.state.Rows++
+			if e.OnRow != nil {
+				e.OnRow(row)
+			}
+		}
+		return w.Flush()
+	}
+
+	for {
+		var top, page []Reply
+		var next string
+		var end bool
+		if err := e.retry(ctx, func() (err error) {
+			top, page, next, end, err = e.main(e.state.Offset)
+			return err
+		}); err != nil {
+			return written, fmt.Errorf("list replies: %w", err)
+		}
+		// 置顶评论仅在首页返回, 与首页一同导出并记入 Done
+		if e.state.Offset == "" {
+			page = withTop(top, page)
+		}
+
+		done := map[int64]bool{}
+		for _, id := range e.state.Done {
+			done[id] = true
+		}
+		for _, root := range page {
+			if done[root.Rpid] {
+				continue
+			}
+			if e.state.Root != root.Rpid {
+				if err := write([]Reply{root}); err != nil {
+					return written, err
+				}
+				e.state.Root, e.state.RootPages = root.Rpid, 0
+				if err := e.save(); err != nil {
+					return written, err
+				}
+			}
+			if err := e.exportReplies(ctx, root, write); err != nil {
+				return written, fmt.Errorf("list replies of %d: %w", root.Rpid, err)
+			}
+			e.state.Done = append(e.state.Done, root.Rpid)
+			e.state.Root, e.state.RootPages = 0, 0
+			if err := e.save(); err != nil {
+				return written, err
+			}
+		}
+
+		if end || len(page) == 0 || next == "" {
+			e.state.Finished = true
+			return written, e.save()
+		}
+		e.state.Offset, e.state.Done = next, nil
+		if err := e.save(); err != nil {
+			return written, err
+		}
+	}
+}
+
+// 将置顶评论放在首页评论之前, 去除重复出现的评论
+func withTop(top, page []Reply) []Reply {
+	if len(top) == 0 {
+		return page
+	}
+	merged := append([]Reply{}, top...)
+	pinned := map[int64]bool{}
+	for _, r := range top {
+		pinned[r.Rpid] = true
+	}
+	for _, r := range page {
+		if !pinned[r.Rpid] {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+// 导出一级评论的全部回复, 从断点中已完成的页之后继续
+func (e *Exporter) exportReplies(ctx context.Context, root Reply, write func([]Reply) error) error {
+	if root.Rcount == 0 && len(root.Replies) == 0 {
+		return nil
+	}
+	fetched := e.state.RootPages * 20
+	for pn := e.state.RootPages + 1; ; pn++ {
+		var replies []Reply
+		var count int
+		if err := e.retry(ctx, func() (err error) {
+			replies, count, err = e.replies(root.Rpid, pn)
+			return err
+		}); err != nil {
+			return err
+		}
+		if err := write(replies); err != nil {
+			return err
+		}
+		fetched += len(replies)
+		e.state.RootPages = pn
+		if err := e.save(); err != nil {
+			return err
+		}
+		if len(replies) == 0 || fetched >= count {
+			return nil
+		}
+	}
+}
+
+func (e *Exporter) retry(ctx context.Context, fn func() error) error {
+	return misc.Retry(ctx, e.Retries, e.RetryWait, func() error {
+		if err := e.throttle.Wait(ctx); err != nil {
+			return err
+		}
+		return fn()
+	})
+}
+
+func (e *Exporter) load() error {
+	e.state = exportState{}
+	b, err := os.ReadFile(e.Checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &e.state); err != nil {
+		return fmt.Errorf("invalid checkpoint %s: %w", e.Checkpoint, err)
+	}
+	return nil
+}
+
+// 写入断点, 先写临时文件再替换, 避免中断时损坏
+func (e *Exporter) save() error {
+	b, err := json.Marshal(&e.state)
+	if err != nil {
+		return err
+	}
+	tmp := e.Checkpoint + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.Checkpoint)
+}
+
+// 导出行写入器
+type rowWriter interface {
+	Write(row ExportRow) error
+	Flush() error
+	Close() error
+}
+
+// 以追加方式打开输出文件, 上次中断留下的不完整行另起一行, CSV 在文件为空时写入表头
+func (e *Exporter) open() (rowWriter, error) {
+	partial, size, err := endsWithPartialLine(e.Output)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(e.Output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if partial {
+		if _, err := f.Write([]byte("\n")); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	switch e.Format {
+	case FormatCSV:
+		w := &csvRowWriter{f: f, w: csv.NewWriter(f)}
+		if size == 0 {
+			if err := w.w.Write(CSVHeader); err != nil {
+				f.Close()
+				return nil, err
+			}
+		}
+		return w, nil
+	case FormatJSONL, "":
+		bw := bufio.NewWriter(f)
+		return &jsonRowWriter{f: f, w: bw, enc: json.NewEncoder(bw)}, nil
+	default:
+		f.Close()
+		return nil, fmt.Errorf("unsupported format %q", e.Format)
+	}
+}
+
+func endsWithPartialLine(path string) (partial bool, size int64, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return false, 0, err
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil && err != io.EOF {
+		return false, 0, err
+	}
+	return !bytes.Equal(last, []byte("\n")), info.Size(), nil
+}
+
+type jsonRowWriter struct {
+	f   *os.File
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (j *jsonRowWriter) Write(row ExportRow) error { return j.enc.Encode(&row) }
+func (j *jsonRowWriter) Flush() error              { return j.w.Flush() }
+func (j *jsonRowWriter) Close() error {
+	j.w.Flush()
+	return j.f.Close()
+}
+
+type csvRowWriter struct {
+	f *os.File
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) Write(row ExportRow) error { return c.w.Write(row.CSV()) }
+func (c *csvRowWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+func (c *csvRowWriter) Close() error {
+	c.w.Flush()
+	return c.f.Close()
+}
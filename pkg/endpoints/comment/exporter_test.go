@@ -0,0 +1,139 @@
+package comment
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 两页一级评论: [1, 2], [3]; 评论 2 有 25 条回复
+func newTestExporter(output string, failReplyPage int) *Exporter {
+	pages := map[string][]Reply{
+		"":   {{Rpid: 1, Content: Content{Message: "[doge][笑哭] @B", Emote: map[string]Emote{"[笑哭]": {ID: 2}, "[doge]": {ID: 1}}, Members: []AtMember{{Mid: "8", Uname: "B"}}}}, {Rpid: 2, Rcount: 25}},
+		"p2": {{Rpid: 3}},
+	}
+	e := &Exporter{Output: output, Format: FormatJSONL, Retries: 1}
+	if strings.HasSuffix(output, ".csv") {
+		e.Format = FormatCSV
+	}
+	e.main = func(offset string) ([]Reply, []Reply, string, bool, error) {
+		if offset == "" {
+			return nil, pages[offset], "p2", false, nil
+		}
+		return nil, pages[offset], "", true, nil
+	}
+	e.replies = func(root int64, pn int) ([]Reply, int, error) {
+		if pn == failReplyPage {
+			failReplyPage = 0
+			return nil, 0, errors.New("banned")
+		}
+		var list []Reply
+		for i := (pn - 1) * 20; i < pn*20 && i < 25; i++ {
+			list = append(list, Reply{Rpid: root*1000 + int64(100+i), Root: root, Parent: root})
+		}
+		return list, 25, nil
+	}
+	return e
+}
+
+func TestExporterResume(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "replies.jsonl")
+
+	e := newTestExporter(output, 2)
+	n, err := e.Run(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 22, n) // 1, 2, 以及第一页回复
+
+	n, err = e.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+
+	n, err = e.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	b, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 28)
+
+	seen := map[int64]bool{}
+	for _, line := range lines {
+		var row ExportRow
+		assert.NoError(t, json.Unmarshal([]byte(line), &row))
+		assert.False(t, seen[row.Rpid], row.Rpid)
+		seen[row.Rpid] = true
+	}
+
+	var first ExportRow
+	json.Unmarshal([]byte(lines[0]), &first)
+	assert.Equal(t, []RowEmote{{ID: 1, Text: "[doge]"}, {ID: 2, Text: "[笑哭]"}}, first.Emotes)
+	assert.Equal(t, []AtMember{{Mid: "8", Uname: "B"}}, first.At)
+}
+
+func TestExporterCSV(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "replies.csv")
+
+	e := newTestExporter(output, 2)
+	_, err := e.Run(context.Background())
+	assert.Error(t, err)
+	_, err = e.Run(context.Background())
+	assert.NoError(t, err)
+
+	f, err := os.Open(output)
+	assert.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 29)
+	assert.Equal(t, CSVHeader, records[0])
+	assert.Equal(t, "[doge];[笑哭]", records[1][13])
+	assert.Equal(t, "1;2", records[1][14])
+	assert.Equal(t, "8", records[1][15])
+	assert.Equal(t, "B", records[1][16])
+}
+
+func TestExporterPinned(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "replies.jsonl")
+
+	// 置顶评论 9 有 25 条回复, 且同时出现在首页评论中
+	e := newTestExporter(output, 2)
+	main := e.main
+	e.main = func(offset string) ([]Reply, []Reply, string, bool, error) {
+		_, page, next, end, err := main(offset)
+		if offset == "" {
+			pinned := Reply{Rpid: 9, Rcount: 25}
+			return []Reply{pinned}, append([]Reply{pinned}, page...), next, end, err
+		}
+		return nil, page, next, end, err
+	}
+
+	n, err := e.Run(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 21, n) // 9 以及第一页回复
+
+	_, err = e.Run(context.Background())
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 54)
+
+	seen := map[int64]bool{}
+	for _, line := range lines {
+		var row ExportRow
+		assert.NoError(t, json.Unmarshal([]byte(line), &row))
+		assert.False(t, seen[row.Rpid], row.Rpid)
+		seen[row.Rpid] = true
+	}
+	assert.True(t, seen[9])
+	assert.True(t, seen[9124])
+}
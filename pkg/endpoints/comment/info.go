@@ -0,0 +1,487 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 评论区类型
+const (
+	TypeVideo   = 1  // 视频稿件, oid 为 avid
+	TypeArticle = 12 // 专栏, oid 为 cvid
+	TypeAudio   = 14 // 音频, oid 为 auid
+	TypeDynamic = 17 // 动态, oid 为动态 id
+)
+
+// 评论排序方式
+const (
+	ModeHot  = 3 // 按热度
+	ModeTime = 2 // 按时间
+)
+
+// 获取评论区明细 (懒加载)
+//
+// Parameters:
+//   - oid (int): 目标评论区 id, 见 TypeVideo 等
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - mode (int): 排序方式, 见 ModeHot 等, 默认为 ModeHot
+//   - offset (string): 翻页游标, 首页为空, 之后为上一页的 Cursor.PaginationReply.NextOffset
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 未登录时只能获取少量评论
+//   - 鉴权方式：Wbi 签名
+//
+// 备注：
+//   - 置顶评论仅在首页返回, 见 TopReplies
+func (c *Comment) Main(oid, typ, mode int, offset string) (*MainResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/reply/wbi/main"
+
+	if mode == 0 {
+		mode = ModeHot
+	}
+	pagination, err := json.Marshal(map[string]string{"offset": offset})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("oid", fmt.Sprintf("%d", oid))
+	params.Set("type", fmt.Sprintf("%d", typ))
+	params.Set("mode", fmt.Sprintf("%d", mode))
+	params.Set("pagination_str", string(pagination))
+	params.Set("plat", "1")
+	params.Set("web_location", "1315875")
+	if offset == "" {
+		params.Set("seek_rpid", "")
+	}
+
+	newUrl, err := login.New(c.client).SignAndGenerateURL(baseURL + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.HTTPClient.R().
+		SetHeader("User-Agent", c.client.UserAgent).
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: c.client.SESSDATA,
+		}).
+		SetResult(&MainResponse{}).
+		Get(newUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*MainResponse), nil
+}
+
+// 遍历评论区的全部一级评论
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - mode (int): 排序方式, 见 ModeHot 等
+//   - offset (string): 起始游标, 为空时从首页开始
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+//
+// 备注：
+//   - 不包含置顶评论
+func (c *Comment) MainIter(ctx context.Context, oid, typ, mode int, offset string, maxItems int) *misc.CursorPager[Reply] {
+	return misc.NewCursorPager(ctx, offset, maxItems, func(cursor string) ([]Reply, string, bool, error) {
+		resp, err := c.Main(oid, typ, mode, cursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if resp.Code != 0 {
+			return nil, "", false, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		cur := resp.Data.Cursor
+		return resp.Data.Replies, cur.PaginationReply.NextOffset, cur.IsEnd, nil
+	})
+}
+
+// 获取置顶评论, 包括 UP 主置顶与管理员置顶
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+func (c *Comment) Pinned(oid, typ int) ([]Reply, error) {
+	resp, err := c.Main(oid, typ, ModeHot, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+	}
+	return resp.Data.TopReplies, nil
+}
+
+// 获取指定评论的回复 (二级评论)
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - root (int64): 根评论 rpid
+//   - pn (int): 页码, 默认为1
+//   - ps (int): 每页项数, 默认为20, 最大为20
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+func (c *Comment) Replies(oid, typ int, root int64, pn, ps int) (*RepliesResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/reply/reply"
+
+	if pn == 0 {
+		pn = 1
+	}
+	if ps == 0 {
+		ps = 20
+	}
+
+	formData := map[string]string{
+		"oid":  fmt.Sprintf("%d", oid),
+		"type": fmt.Sprintf("%d", typ),
+		"root": fmt.Sprintf("%d", root),
+		"pn":   fmt.Sprintf("%d", pn),
+		"ps":   fmt.Sprintf("%d", ps),
+	}
+
+	resp, err := c.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetHeader("User-Agent", c.client.UserAgent).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: c.client.SESSDATA,
+		}).
+		SetResult(&RepliesResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*RepliesResponse), nil
+}
+
+// 遍历指定评论的全部回复
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - root (int64): 根评论 rpid
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (c *Comment) RepliesIter(ctx context.Context, oid, typ int, root int64, maxItems int) *misc.Pager[Reply] {
+	return misc.NewPager(ctx, 20, 20, maxItems, func(pn, ps int) ([]Reply, int, error) {
+		resp, err := c.Replies(oid, typ, root, pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Replies, resp.Data.Page.Count, nil
+	})
+}
+
+// 获取根评论及其全部回复, 按回复关系组成树
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+//   - root (Reply): 根评论
+func (c *Comment) Thread(ctx context.Context, oid, typ int, root Reply) (*ReplyNode, error) {
+	subs, err := c.RepliesIter(ctx, oid, typ, root.Rpid, 0).All()
+	if err != nil {
+		return nil, err
+	}
+	return BuildThread(root, subs), nil
+}
+
+// 获取评论区总评论数
+//
+// Parameters:
+//   - oid (int): 目标评论区 id
+//   - typ (int): 评论区类型, 见 TypeVideo 等
+func (c *Comment) Count(oid, typ int) (*CountResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/reply/count"
+
+	formData := map[string]string{
+		"oid":  fmt.Sprintf("%d", oid),
+		"type": fmt.Sprintf("%d", typ),
+	}
+
+	resp, err := c.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetResult(&CountResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*CountResponse), nil
+}
+
+// ReplyNode 评论树节点
+type ReplyNode struct {
+	Reply    Reply        // 评论本体, Replies 字段不再使用
+	Children []*ReplyNode // 直接回复该评论的评论, 按时间排序
+}
+
+// 将根评论与其全部回复按 parent 组成树
+//
+// 备注：
+//   - parent 不在列表中的回复 (如已删除) 挂在根评论下
+func BuildThread(root Reply, subs []Reply) *ReplyNode {
+	root.Replies = nil
+	tree := &ReplyNode{Reply: root}
+	nodes := map[int64]*ReplyNode{root.Rpid: tree}
+	for _, r := range subs {
+		r.Replies = nil
+		nodes[r.Rpid] = &ReplyNode{Reply: r}
+	}
+	for _, r := range subs {
+		parent, ok := nodes[r.Parent]
+		if !ok || r.Parent == r.Rpid {
+			parent = tree
+		}
+		parent.Children = append(parent.Children, nodes[r.Rpid])
+	}
+	return tree
+}
+
+// Walk 深度优先遍历, 根节点深度为0, fn 返回错误时停止
+func (n *ReplyNode) Walk(fn func(node *ReplyNode, depth int) error) error {
+	return n.walk(fn, 0)
+}
+
+func (n *ReplyNode) walk(fn func(node *ReplyNode, depth int) error, depth int) error {
+	if err := fn(n, depth); err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Size 返回树中的评论数
+func (n *ReplyNode) Size() int {
+	size := 0
+	n.Walk(func(*ReplyNode, int) error {
+		size++
+		return nil
+	})
+	return size
+}
+
+// MainResponse 评论区明细
+type MainResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -404表示无此项, 12002表示评论区已关闭, 12009表示评论主体的type不合法
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Cursor     Cursor  `json:"cursor"`      // 游标信息
+		Replies    []Reply `json:"replies"`     // 评论列表
+		TopReplies []Reply `json:"top_replies"` // 置顶评论, 仅首页返回
+		Upper      struct {
+			Mid int `json:"mid"` // UP 主 mid
+		} `json:"upper"` // UP 主信息
+		Assist    int         `json:"assist"`    // 作用尚不明确
+		Blacklist int         `json:"blacklist"` // 作用尚不明确
+		Vote      int         `json:"vote"`      // 投票评论
+		Config    interface{} `json:"config"`    // 评论区配置
+		Control   struct {
+			InputDisable     bool   `json:"input_disable"`      // 是否禁止新增评论
+			RootInputText    string `json:"root_input_text"`    // 评论框文字
+			ChildInputText   string `json:"child_input_text"`   // 回复框文字
+			GiveUpInputText  string `json:"giveup_input_text"`  // 放弃评论后的文字
+			AnswerGuideText  string `json:"answer_guide_text"`  // 答题页面链接文字
+			BgText           string `json:"bg_text"`            // 空评论区文字
+			WebSelection     bool   `json:"web_selection"`      // 评论是否筛选后可见
+			ShowType         int    `json:"show_type"`          // 作用尚不明确
+			ShowText         string `json:"show_text"`          // 作用尚不明确
+			DisableJumpEmote bool   `json:"disable_jump_emote"` // 作用尚不明确
+		} `json:"control"` // 评论区输入属性
+	} `json:"data"` // 数据本体
+}
+
+// Cursor 评论区游标信息
+type Cursor struct {
+	IsBegin         bool   `json:"is_begin"`  // 当前页是否为首页
+	Prev            int    `json:"prev"`      // 上页页码
+	Next            int    `json:"next"`      // 下页页码
+	IsEnd           bool   `json:"is_end"`    // 是否已到末尾
+	Mode            int    `json:"mode"`      // 排序方式
+	ModeText        string `json:"mode_text"` // 排序方式名称
+	AllCount        int    `json:"all_count"` // 全部评论条数
+	SessionID       string `json:"session_id"`
+	Name            string `json:"name"` // 评论区名称
+	PaginationReply struct {
+		NextOffset string `json:"next_offset"` // 下一页游标
+		PrevOffset string `json:"prev_offset"` // 上一页游标
+	} `json:"pagination_reply"` // 翻页游标
+}
+
+// RepliesResponse 评论的回复列表
+type RepliesResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -404表示无此项, 12002表示评论区已关闭, 12009表示评论主体的type不合法
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Page struct {
+			Num   int `json:"num"`   // 当前页码
+			Size  int `json:"size"`  // 每页项数
+			Count int `json:"count"` // 回复总数
+		} `json:"page"` // 页信息
+		Root    Reply   `json:"root"`    // 根评论
+		Replies []Reply `json:"replies"` // 回复列表
+		Upper   struct {
+			Mid int `json:"mid"` // UP 主 mid
+		} `json:"upper"` // UP 主信息
+	} `json:"data"` // 数据本体
+}
+
+// CountResponse 评论区评论总数
+type CountResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -404表示无此项, 12002表示评论区已关闭
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Count int `json:"count"` // 评论总数, 包括回复
+	} `json:"data"` // 数据本体
+}
+
+// Reply 评论
+type Reply struct {
+	Rpid      int64   `json:"rpid"`       // 评论 rpid
+	Oid       int     `json:"oid"`        // 评论区 id
+	Type      int     `json:"type"`       // 评论区类型
+	Mid       int     `json:"mid"`        // 发送者 mid
+	Root      int64   `json:"root"`       // 根评论 rpid, 一级评论为0
+	Parent    int64   `json:"parent"`     // 回复的评论 rpid, 一级评论为0, 二级评论为根评论 rpid
+	Dialog    int64   `json:"dialog"`     // 所在对话的 rpid
+	Count     int     `json:"count"`      // 回复数
+	Rcount    int     `json:"rcount"`     // 回复数
+	State     int     `json:"state"`      // 作用尚不明确
+	FansGrade int     `json:"fansgrade"`  // 是否具有粉丝标签, 0: 无, 1: 有
+	Attr      int     `json:"attr"`       // 属性位
+	Ctime     int64   `json:"ctime"`      // 发送时间
+	RpidStr   string  `json:"rpid_str"`   // 评论 rpid 字符串形式
+	RootStr   string  `json:"root_str"`   // 根评论 rpid 字符串形式
+	ParentStr string  `json:"parent_str"` // 回复的评论 rpid 字符串形式
+	Like      int     `json:"like"`       // 点赞数
+	Action    int     `json:"action"`     // 当前用户操作状态, 0: 无, 1: 已点赞, 2: 已点踩
+	Member    Member  `json:"member"`     // 发送者信息
+	Content   Content `json:"content"`    // 评论内容
+	Replies   []Reply `json:"replies"`    // 部分回复预览, 完整回复见 Comment.Replies
+	Invisible bool    `json:"invisible"`  // 评论是否被隐藏
+	UpAction  struct {
+		Like  bool `json:"like"`  // UP 主是否点赞
+		Reply bool `json:"reply"` // UP 主是否回复
+	} `json:"up_action"` // UP 主操作
+	ReplyControl struct {
+		SubReplyEntryText string `json:"sub_reply_entry_text"` // 回复提示, 如 "共 6 条回复"
+		SubReplyTitleText string `json:"sub_reply_title_text"` // 回复提示, 如 "相关回复共6条"
+		TimeDesc          string `json:"time_desc"`            // 时间提示, 如 "1天前发布"
+		Location          string `json:"location"`             // IP 属地, 如 "IP属地：广东"
+	} `json:"reply_control"` // 评论提示文案
+	Folder struct {
+		HasFolded bool   `json:"has_folded"` // 是否有被折叠的回复
+		IsFolded  bool   `json:"is_folded"`  // 是否被折叠
+		Rule      string `json:"rule"`       // 折叠规则说明页
+	} `json:"folder"` // 折叠信息
+}
+
+// Location 返回去掉前缀的 IP 属地, 如 "广东"
+func (r *Reply) Location() string {
+	loc := r.ReplyControl.Location
+	for _, prefix := range []string{"IP属地：", "IP属地:"} {
+		loc = strings.TrimPrefix(loc, prefix)
+	}
+	return loc
+}
+
+// Member 评论发送者
+type Member struct {
+	Mid       string `json:"mid"`    // 用户 mid, 注意为字符串
+	Uname     string `json:"uname"`  // 昵称
+	Sex       string `json:"sex"`    // 性别
+	Sign      string `json:"sign"`   // 签名
+	Avatar    string `json:"avatar"` // 头像
+	LevelInfo struct {
+		CurrentLevel int `json:"current_level"` // 用户等级
+	} `json:"level_info"` // 等级信息
+	Vip struct {
+		VipType   int `json:"vipType"`   // 会员类型, 0: 无, 1: 月度, 2: 年度及以上
+		VipStatus int `json:"vipStatus"` // 会员状态, 0: 无, 1: 有
+	} `json:"vip"` // 会员信息
+	OfficialVerify struct {
+		Type int    `json:"type"` // 认证类型, -1: 无, 0: 个人, 1: 机构
+		Desc string `json:"desc"` // 认证信息
+	} `json:"official_verify"` // 认证信息
+	Pendant    interface{} `json:"pendant"`     // 头像框
+	Nameplate  interface{} `json:"nameplate"`   // 勋章
+	FansDetail interface{} `json:"fans_detail"` // 粉丝标签, 无时为 null
+}
+
+// Content 评论内容
+type Content struct {
+	Message     string             `json:"message"`        // 评论正文
+	Members     []AtMember         `json:"members"`        // 被 @ 的用户
+	AtNameToMid map[string]int     `json:"at_name_to_mid"` // 被 @ 的昵称到 mid 的映射
+	Emote       map[string]Emote   `json:"emote"`          // 表情, 键为正文中的表情文本, 如 "[doge]"
+	JumpURL     map[string]JumpURL `json:"jump_url"`       // 高亮跳转, 键为正文中的关键词或链接
+	MaxLine     int                `json:"max_line"`       // 收起最大行数
+	Pictures    []Picture          `json:"pictures"`       // 评论图片
+}
+
+// AtMember 被 @ 的用户
+type AtMember struct {
+	Mid   string `json:"mid"`   // 用户 mid
+	Uname string `json:"uname"` // 昵称
+}
+
+// Emote 评论中的表情
+type Emote struct {
+	ID        int    `json:"id"`         // 表情 id
+	PackageID int    `json:"package_id"` // 表情包 id
+	State     int    `json:"state"`      // 作用尚不明确
+	Type      int    `json:"type"`       // 表情类型, 1: 免费, 2: 会员专属, 3: 购买所得, 4: 颜文字
+	Attr      int    `json:"attr"`       // 作用尚不明确
+	Text      string `json:"text"`       // 表情文本
+	URL       string `json:"url"`        // 表情图片 url
+	Meta      struct {
+		Size  int    `json:"size"`  // 表情尺寸, 1: 小, 2: 大
+		Alias string `json:"alias"` // 简写名
+	} `json:"meta"` // 属性
+	Mtime     int64  `json:"mtime"`      // 创建时间
+	JumpTitle string `json:"jump_title"` // 表情名称
+}
+
+// JumpURL 评论中的高亮跳转
+type JumpURL struct {
+	Title      string `json:"title"`          // 显示文字
+	PrefixIcon string `json:"prefix_icon"`    // 前缀图标
+	PCURL      string `json:"pc_url"`         // PC 端跳转 url
+	AppURL     string `json:"app_url_schema"` // APP 端跳转 url
+	State      int    `json:"state"`          // 作用尚不明确
+}
+
+// Picture 评论中的图片
+type Picture struct {
+	ImgSrc    string  `json:"img_src"`    // 图片 url
+	ImgWidth  int     `json:"img_width"`  // 宽度
+	ImgHeight int     `json:"img_height"` // 高度
+	ImgSize   float64 `json:"img_size"`   // 大小, 单位为 KB
+}
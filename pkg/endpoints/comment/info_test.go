@@ -0,0 +1,68 @@
+package comment
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const replyJSON = `{
+	"rpid": 100, "oid": 170001, "type": 1, "mid": 7, "root": 0, "parent": 0, "rcount": 3, "ctime": 1700000000,
+	"rpid_str": "100", "like": 42,
+	"member": {"mid": "7", "uname": "用户A", "level_info": {"current_level": 6}, "vip": {"vipType": 2, "vipStatus": 1}},
+	"content": {
+		"message": "[doge] 回复 @用户B :看 BV17x411w7KC",
+		"members": [{"mid": "8", "uname": "用户B"}],
+		"at_name_to_mid": {"用户B": 8},
+		"emote": {"[doge]": {"id": 26, "package_id": 1, "text": "[doge]", "url": "http://i0.hdslb.com/doge.png", "meta": {"size": 1}}},
+		"jump_url": {"BV17x411w7KC": {"title": "视频", "pc_url": "https://www.bilibili.com/video/BV17x411w7KC"}},
+		"pictures": [{"img_src": "http://i0.hdslb.com/a.jpg", "img_width": 100, "img_height": 50, "img_size": 12.5}]
+	},
+	"replies": [{"rpid": 101, "root": 100, "parent": 100}],
+	"reply_control": {"location": "IP属地：广东"}
+}`
+
+func TestReply(t *testing.T) {
+	var r Reply
+	assert.NoError(t, json.Unmarshal([]byte(replyJSON), &r))
+	assert.Equal(t, int64(100), r.Rpid)
+	assert.Equal(t, "7", r.Member.Mid)
+	assert.Equal(t, 6, r.Member.LevelInfo.CurrentLevel)
+	assert.Equal(t, 26, r.Content.Emote["[doge]"].ID)
+	assert.Equal(t, 8, r.Content.AtNameToMid["用户B"])
+	assert.Equal(t, "视频", r.Content.JumpURL["BV17x411w7KC"].Title)
+	assert.Equal(t, 12.5, r.Content.Pictures[0].ImgSize)
+	assert.Equal(t, "广东", r.Location())
+
+	subs := []Reply{
+		{Rpid: 101, Root: 100, Parent: 100},
+		{Rpid: 102, Root: 100, Parent: 101},
+		{Rpid: 103, Root: 100, Parent: 999}, // 回复的评论已删除
+		{Rpid: 104, Root: 100, Parent: 102},
+	}
+	tree := BuildThread(r, subs)
+	assert.Nil(t, tree.Reply.Replies)
+	assert.Equal(t, 5, tree.Size())
+
+	var visited []int64
+	var depths []int
+	tree.Walk(func(n *ReplyNode, depth int) error {
+		visited = append(visited, n.Reply.Rpid)
+		depths = append(depths, depth)
+		return nil
+	})
+	assert.Equal(t, []int64{100, 101, 102, 104, 103}, visited)
+	assert.Equal(t, []int{0, 1, 2, 3, 1}, depths)
+
+	stop := errors.New("stop")
+	n := 0
+	err := tree.Walk(func(*ReplyNode, int) error {
+		if n++; n == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+}
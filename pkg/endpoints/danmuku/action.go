@@ -1 +1,334 @@
 package danmuku
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 发送视频弹幕
+//
+// Parameters:
+//   - aid (int): 稿件 avid, 与 bvid 任选一个
+//   - bvid (string): 稿件 bvid, 与 aid 任选一个
+//   - cid (int): 视频 cid
+//   - msg (string): 弹幕内容, 长度小于 100 字符
+//   - progress (int): 弹幕出现在视频内的时间, 单位为毫秒
+//   - color (int): 弹幕颜色, 十进制 RGB888 值, 如 16777215 为白色
+//   - mode (int): 弹幕类型, 1: 普通弹幕, 4: 底部弹幕, 5: 顶部弹幕
+//   - fontsize (int): 弹幕字号, 18: 小, 25: 标准, 36: 大
+//   - pool (int): 弹幕池, 0: 普通池, 1: 字幕池 (需 UP 主权限), 2: 特殊池
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (d *Danmuku) Post(aid int, bvid string, cid int, msg string, progress, color, mode, fontsize, pool int) (*PostResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/post"
+
+	formData := map[string]string{
+		"type":     "1",
+		"oid":      fmt.Sprintf("%d", cid),
+		"msg":      msg,
+		"aid":      fmt.Sprintf("%d", aid),
+		"bvid":     bvid,
+		"progress": fmt.Sprintf("%d", progress),
+		"color":    fmt.Sprintf("%d", color),
+		"fontsize": fmt.Sprintf("%d", fontsize),
+		"pool":     fmt.Sprintf("%d", pool),
+		"mode":     fmt.Sprintf("%d", mode),
+		"rnd":      fmt.Sprintf("%d", time.Now().UnixNano()/int64(time.Microsecond)),
+		"plat":     "1",
+		"csrf":     d.client.CSRF,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&PostResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*PostResponse), nil
+}
+
+// 撤回自己发送的弹幕
+//
+// Parameters:
+//   - cid (int): 视频 cid
+//   - dmid (int64): 弹幕 dmid
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+//
+// 备注：
+//   - 仅能撤回 2 分钟内发送的弹幕, 每天 3 次
+func (d *Danmuku) Recall(cid int, dmid int64) (*RecallResponse, error) {
+	baseURL := "https://api.bilibili.com/x/dm/recall"
+
+	formData := map[string]string{
+		"cid":  fmt.Sprintf("%d", cid),
+		"dmid": fmt.Sprintf("%d", dmid),
+		"csrf": d.client.CSRF,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&RecallResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*RecallResponse), nil
+}
+
+// 点赞弹幕
+//
+// Parameters:
+//   - cid (int): 视频 cid
+//   - dmid (int64): 弹幕 dmid
+//   - op (int): 操作方式, 1: 点赞, 2: 取消点赞
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (d *Danmuku) Like(cid int, dmid int64, op int) (*misc.BaseResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/thumbup/add"
+
+	formData := map[string]string{
+		"oid":      fmt.Sprintf("%d", cid),
+		"dmid":     fmt.Sprintf("%d", dmid),
+		"op":       fmt.Sprintf("%d", op),
+		"platform": "web_player",
+		"csrf":     d.client.CSRF,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
+
+// 查询弹幕点赞数
+//
+// Parameters:
+//   - cid (int): 视频 cid
+//   - dmids ([]int64): 弹幕 dmid 列表
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 登录后返回是否已点赞
+func (d *Danmuku) LikeStats(cid int, dmids []int64) (*LikeStatsResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/thumbup/stats"
+
+	formData := map[string]string{
+		"oid": fmt.Sprintf("%d", cid),
+		"ids": joinIDs(dmids),
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&LikeStatsResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*LikeStatsResponse), nil
+}
+
+// 举报弹幕
+//
+// Parameters:
+//   - cid (int): 视频 cid
+//   - dmid (int64): 弹幕 dmid
+//   - reason (int): 举报原因, 1: 违法违禁, 2: 色情低俗, 3: 赌博诈骗, 4: 人身攻击, 5: 侵犯隐私, 6: 垃圾广告, 7: 引战, 8: 剧透, 9: 恶意刷屏, 10: 视频无关, 11: 其他, 12: 青少年不良信息
+//   - content (string): 举报详细描述, reason 为 11 时必要
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (d *Danmuku) Report(cid int, dmid int64, reason int, content string) (*misc.BaseResponse, error) {
+	baseURL := "https://api.bilibili.com/x/dm/report/add"
+
+	formData := map[string]string{
+		"cid":     fmt.Sprintf("%d", cid),
+		"dmid":    fmt.Sprintf("%d", dmid),
+		"reason":  fmt.Sprintf("%d", reason),
+		"content": content,
+		"csrf":    d.client.CSRF,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
+
+// 修改弹幕状态 (UP 主)
+//
+// Parameters:
+//   - cid (int): 视频 cid
+//   - dmids ([]int64): 弹幕 dmid 列表
+//   - state (int): 操作, 1: 删除弹幕, 2: 保护弹幕, 3: 取消保护
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+//   - 需要为视频 UP 主或协管
+func (d *Danmuku) EditState(cid int, dmids []int64, state int) (*misc.BaseResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/edit/state"
+
+	formData := map[string]string{
+		"type":  "1",
+		"oid":   fmt.Sprintf("%d", cid),
+		"dmids": joinIDs(dmids),
+		"state": fmt.Sprintf("%d", state),
+		"csrf":  d.client.CSRF,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
+
+// 删除弹幕 (UP 主), 见 EditState
+func (d *Danmuku) Delete(cid int, dmids []int64) (*misc.BaseResponse, error) {
+	return d.EditState(cid, dmids, 1)
+}
+
+// 保护或取消保护弹幕 (UP 主), 见 EditState
+func (d *Danmuku) Protect(cid int, dmids []int64, protect bool) (*misc.BaseResponse, error) {
+	if protect {
+		return d.EditState(cid, dmids, 2)
+	}
+	return d.EditState(cid, dmids, 3)
+}
+
+// 修改弹幕所在弹幕池 (UP 主)
+//
+// Parameters:
+//   - cid (int): 视频 cid
+//   - dmids ([]int64): 弹幕 dmid 列表
+//   - pool (int): 目标弹幕池, 0: 普通池, 1: 字幕池
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+//   - 需要为视频 UP 主或协管
+func (d *Danmuku) EditPool(cid int, dmids []int64, pool int) (*misc.BaseResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/edit/pool"
+
+	formData := map[string]string{
+		"type":  "1",
+		"oid":   fmt.Sprintf("%d", cid),
+		"dmids": joinIDs(dmids),
+		"pool":  fmt.Sprintf("%d", pool),
+		"csrf":  d.client.CSRF,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
+
+func joinIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("%d", id)
+	}
+	return strings.Join(parts, ",")
+}
+
+//--
+
+// PostResponse 发送弹幕
+type PostResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -102表示账号被封停, -111表示csrf校验失败, -400表示请求错误, -404表示无此项, 36700表示系统升级中, 36701表示弹幕包含被禁止的内容, 36702表示弹幕长度大于100, 36703表示发送频率过快, 36704表示禁止向未审核的视频发送弹幕, 36714表示弹幕发送时间不合法
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Action  string `json:"action"`   // 空, 作用尚不明确
+		Dmid    int64  `json:"dmid"`     // 弹幕 dmid
+		DmidStr string `json:"dmid_str"` // 弹幕 dmid 的字符串形式
+		Visible bool   `json:"visible"`  // 作用尚不明确
+	} `json:"data"`
+}
+
+// -
+
+// RecallResponse 撤回弹幕
+type RecallResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -111表示csrf校验失败, -400表示请求错误, 36301表示撤回次数不足, 36303表示弹幕发送已超过2分钟
+	Message string `json:"message"` // 错误信息, 成功时为剩余撤回次数提示
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+}
+
+// -
+
+// LikeStatsResponse 弹幕点赞数
+type LikeStatsResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    map[string]struct {
+		Likes    int    `json:"likes"`     // 点赞数
+		UserLike int    `json:"user_like"` // 当前用户是否点赞, 0: 未点赞, 1: 已点赞
+		IDStr    string `json:"id_str"`    // 弹幕 dmid 的字符串形式
+	} `json:"data"` // 以 dmid 为键
+}
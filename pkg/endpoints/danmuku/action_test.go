@@ -0,0 +1,81 @@
+package danmuku
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionForms(t *testing.T) {
+	c, rt := tests.NewRecordClient()
+	d := New(c)
+
+	calls := []struct {
+		name string
+		call func() error
+		url  string
+		form map[string]string
+	}{
+		{"Post", func() error {
+			_, err := d.Post(1, "BV1xx", 2, "弹幕", 1500, 16777215, 1, 25, 0)
+			return err
+		}, "https://api.bilibili.com/x/v2/dm/post", map[string]string{
+			"type": "1", "oid": "2", "msg": "弹幕", "aid": "1", "bvid": "BV1xx", "progress": "1500",
+			"color": "16777215", "mode": "1", "fontsize": "25", "pool": "0", "plat": "1",
+		}},
+		{"Recall", func() error {
+			_, err := d.Recall(2, 300)
+			return err
+		}, "https://api.bilibili.com/x/dm/recall", map[string]string{"cid": "2", "dmid": "300"}},
+		{"Like", func() error {
+			_, err := d.Like(2, 300, 2)
+			return err
+		}, "https://api.bilibili.com/x/v2/dm/thumbup/add", map[string]string{"oid": "2", "dmid": "300", "op": "2", "platform": "web_player"}},
+		{"Report", func() error {
+			_, err := d.Report(2, 300, 11, "其他原因")
+			return err
+		}, "https://api.bilibili.com/x/dm/report/add", map[string]string{"cid": "2", "dmid": "300", "reason": "11", "content": "其他原因"}},
+		{"Delete", func() error {
+			_, err := d.Delete(2, []int64{300, 301})
+			return err
+		}, "https://api.bilibili.com/x/v2/dm/edit/state", map[string]string{"type": "1", "oid": "2", "dmids": "300,301", "state": "1"}},
+		{"Protect", func() error {
+			_, err := d.Protect(2, []int64{300}, true)
+			return err
+		}, "https://api.bilibili.com/x/v2/dm/edit/state", map[string]string{"dmids": "300", "state": "2"}},
+		{"Unprotect", func() error {
+			_, err := d.Protect(2, []int64{300}, false)
+			return err
+		}, "https://api.bilibili.com/x/v2/dm/edit/state", map[string]string{"state": "3"}},
+		{"EditPool", func() error {
+			_, err := d.EditPool(2, []int64{300, 301}, 1)
+			return err
+		}, "https://api.bilibili.com/x/v2/dm/edit/pool", map[string]string{"type": "1", "oid": "2", "dmids": "300,301", "pool": "1"}},
+	}
+
+	for i, c := range calls {
+		assert.NoError(t, c.call(), c.name)
+		req, form := rt.Requests[i], rt.Forms[i]
+		assert.Equal(t, http.MethodPost, req.Method, c.name)
+		assert.Equal(t, c.url, req.URL.String(), c.name)
+		assert.Equal(t, "token", form.Get("csrf"), c.name)
+		for k, v := range c.form {
+			assert.Equal(t, v, form.Get(k), "%s: %s", c.name, k)
+		}
+		cookie, err := req.Cookie("SESSDATA")
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, "sess", cookie.Value, c.name)
+		}
+	}
+	assert.NotEmpty(t, rt.Forms[0].Get("rnd"))
+
+	_, err := d.LikeStats(2, []int64{300, 301})
+	assert.NoError(t, err)
+	req := rt.Requests[len(rt.Requests)-1]
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "/x/v2/dm/thumbup/stats", req.URL.Path)
+	assert.Equal(t, "300,301", req.URL.Query().Get("ids"))
+	assert.Equal(t, "2", req.URL.Query().Get("oid"))
+}
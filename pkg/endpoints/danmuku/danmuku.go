@@ -0,0 +1,11 @@
+package danmuku
+
+import "github.com/Yuelioi/bilibili/pkg/client"
+
+type Danmuku struct {
+	client *client.Client
+}
+
+func New(client *client.Client) *Danmuku {
+	return &Danmuku{client}
+}
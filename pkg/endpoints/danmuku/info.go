@@ -0,0 +1,319 @@
+package danmuku
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+	"github.com/go-resty/resty/v2"
+)
+
+// 每个弹幕分包覆盖的时长, 单位为毫秒
+const SegmentDuration = 6 * 60 * 1000
+
+// 获取实时弹幕分包 (protobuf)
+//
+// Parameters:
+//   - cid (int): 视频的 cid
+//   - aid (int): 视频的 aid (可选)
+//   - segmentIndex (int): 分包序号, 从 1 开始, 每包 6 分钟
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 非必要
+func (d *Danmuku) Segment(cid, aid, segmentIndex int) (*SegmentReply, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/web/seg.so"
+
+	formData := map[string]string{
+		"type":          "1",
+		"oid":           fmt.Sprintf("%d", cid),
+		"segment_index": fmt.Sprintf("%d", segmentIndex),
+	}
+	if aid != 0 {
+		formData["pid"] = fmt.Sprintf("%d", aid)
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetHeader("User-Agent", d.client.UserAgent).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := protoBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSegReply(body)
+}
+
+// 获取视频全部实时弹幕
+//
+// Parameters:
+//   - cid (int): 视频的 cid
+//   - aid (int): 视频的 aid (可选)
+//   - timelength (int): 视频时长, 单位为毫秒, 即 StreamData.Timelength
+//
+// 备注：
+//   - 按 6 分钟一包依次请求, 结果按出现时间排序
+func (d *Danmuku) Segments(cid, aid, timelength int) ([]Danmaku, error) {
+	count := SegmentCount(timelength)
+
+	var all []Danmaku
+	for i := 1; i <= count; i++ {
+		reply, err := d.Segment(cid, aid, i)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", i, err)
+		}
+		all = append(all, reply.Elems...)
+	}
+	SortByProgress(all)
+	return all, nil
+}
+
+// 获取弹幕 (旧版 xml)
+//
+// Parameters:
+//   - cid (int): 视频的 cid
+//
+// 备注：
+//   - 返回数据为 deflate 压缩的 xml, 仅包含部分弹幕
+func (d *Danmuku) XMLList(cid int) ([]Danmaku, error) {
+	baseURL := "https://api.bilibili.com/x/v1/dm/list.so"
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParam("oid", fmt.Sprintf("%d", cid)).
+		SetHeader("User-Agent", d.client.UserAgent).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("request failed with status: %s", resp.Status())
+	}
+
+	body := resp.Body()
+	if strings.EqualFold(resp.Header().Get("Content-Encoding"), "deflate") {
+		body, err = io.ReadAll(flate.NewReader(bytes.NewReader(body)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to inflate body: %w", err)
+		}
+	}
+	return ParseXML(body)
+}
+
+// 查询历史弹幕日期
+//
+// Parameters:
+//   - cid (int): 视频的 cid
+//   - month (string): 查询月份, 格式为 YYYY-MM
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）
+func (d *Danmuku) HistoryIndex(cid int, month string) (*HistoryIndexResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/history/index"
+
+	formData := map[string]string{
+		"type":  "1",
+		"oid":   fmt.Sprintf("%d", cid),
+		"month": month,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&HistoryIndexResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*HistoryIndexResponse), nil
+}
+
+// 获取历史弹幕 (protobuf)
+//
+// Parameters:
+//   - cid (int): 视频的 cid
+//   - date (string): 弹幕日期, 格式为 YYYY-MM-DD, 见 HistoryIndex
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）
+func (d *Danmuku) History(cid int, date string) (*SegmentReply, error) {
+	baseURL := "https://api.bilibili.com/x/v2/dm/web/history/seg.so"
+
+	formData := map[string]string{
+		"type": "1",
+		"oid":  fmt.Sprintf("%d", cid),
+		"date": date,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := protoBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSegReply(body)
+}
+
+// 计算弹幕分包数量
+func SegmentCount(timelength int) int {
+	if timelength <= 0 {
+		return 1
+	}
+	return (timelength + SegmentDuration - 1) / SegmentDuration
+}
+
+// 解析 list.so 返回的 xml 弹幕
+func ParseXML(data []byte) ([]Danmaku, error) {
+	var doc struct {
+		D []struct {
+			P       string `xml:"p,attr"`
+			Content string `xml:",chardata"`
+		} `xml:"d"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	list := make([]Danmaku, 0, len(doc.D))
+	for _, item := range doc.D {
+		dm, err := parseXMLAttr(item.P)
+		if err != nil {
+			// 跳过格式错误的弹幕
+			continue
+		}
+		dm.Content = item.Content
+		list = append(list, dm)
+	}
+	SortByProgress(list)
+	return list, nil
+}
+
+// 解析 xml 弹幕的 p 属性
+//
+// p: 时间,类型,字号,颜色,发送时间,弹幕池,发送者hash,dmid,屏蔽等级 (可选)
+func parseXMLAttr(attr string) (Danmaku, error) {
+	p := strings.Split(attr, ",")
+	if len(p) < 8 {
+		return Danmaku{}, fmt.Errorf("invalid danmaku attr %q", attr)
+	}
+
+	seconds, err1 := strconv.ParseFloat(p[0], 64)
+	mode, err2 := strconv.Atoi(p[1])
+	fontSize, err3 := strconv.Atoi(p[2])
+	color, err4 := strconv.ParseUint(p[3], 10, 32)
+	ctime, err5 := strconv.ParseInt(p[4], 10, 64)
+	pool, err6 := strconv.Atoi(p[5])
+	id, err7 := strconv.ParseInt(p[7], 10, 64)
+	var (
+		weight int
+		err8   error
+	)
+	if len(p) > 8 {
+		weight, err8 = strconv.Atoi(p[8])
+	}
+	if err := errors.Join(err1, err2, err3, err4, err5, err6, err7, err8); err != nil {
+		return Danmaku{}, fmt.Errorf("invalid danmaku attr %q: %w", attr, err)
+	}
+
+	return Danmaku{
+		ID:       id,
+		IDStr:    p[7],
+		Progress: int(math.Round(seconds * 1000)),
+		Mode:     mode,
+		FontSize: fontSize,
+		Color:    uint32(color),
+		MidHash:  p[6],
+		Ctime:    ctime,
+		Pool:     pool,
+		Weight:   weight,
+	}, nil
+}
+
+// 按出现时间排序
+func SortByProgress(list []Danmaku) {
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Progress < list[j].Progress
+	})
+}
+
+// 出错时接口返回 json, 正常时返回 protobuf
+func protoBody(resp *resty.Response) ([]byte, error) {
+	if resp.IsError() {
+		return nil, fmt.Errorf("request failed with status: %s", resp.Status())
+	}
+	body := resp.Body()
+	if strings.Contains(resp.Header().Get("Content-Type"), "json") {
+		var base misc.BaseResponse
+		if err := json.Unmarshal(body, &base); err == nil && base.Code != 0 {
+			return nil, &misc.CodeError{Code: base.Code, Message: base.Message}
+		}
+	}
+	return body, nil
+}
+
+// Danmaku 单条弹幕
+type Danmaku struct {
+	ID        int64  `json:"id"`        // 弹幕 dmid
+	IDStr     string `json:"id_str"`    // 弹幕 dmid 字符串形式
+	Progress  int    `json:"progress"`  // 弹幕出现位置, 单位为毫秒
+	Mode      int    `json:"mode"`      // 弹幕类型, 1 2 3: 普通弹幕, 4: 底部弹幕, 5: 顶部弹幕, 6: 逆向弹幕, 7: 高级弹幕, 8: 代码弹幕, 9: BAS 弹幕
+	FontSize  int    `json:"fontsize"`  // 弹幕字号, 18: 小, 25: 标准, 36: 大
+	Color     uint32 `json:"color"`     // 弹幕颜色, 十进制 RGB888 值
+	MidHash   string `json:"midHash"`   // 发送者 mid hash
+	Content   string `json:"content"`   // 弹幕正文
+	Ctime     int64  `json:"ctime"`     // 发送时间, 秒级时间戳
+	Weight    int    `json:"weight"`    // 权重, 用于智能屏蔽, 0-10
+	Action    string `json:"action"`    // 动作, 作用尚不明确
+	Pool      int    `json:"pool"`      // 弹幕池, 0: 普通池, 1: 字幕池, 2: 特殊池
+	Attr      int    `json:"attr"`      // 弹幕属性位, bit0: 保护, bit1: 直播, bit2: 高赞
+	Animation string `json:"animation"` // 动画
+}
+
+// Time 弹幕出现时间, 单位为秒
+func (d Danmaku) Time() float64 {
+	return float64(d.Progress) / 1000
+}
+
+// SegmentReply 弹幕分包
+type SegmentReply struct {
+	Elems []Danmaku `json:"elems"` // 弹幕列表
+	State int       `json:"state"` // 是否已关闭弹幕, 0: 未关闭, 1: 已关闭
+}
+
+// HistoryIndexResponse 历史弹幕日期
+type HistoryIndexResponse struct {
+	Code    int      `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -400表示请求错误
+	Message string   `json:"message"` // 错误信息, 默认为0
+	TTL     int      `json:"ttl"`     // TTL, 固定值1
+	Data    []string `json:"data"`    // 有历史弹幕的日期列表, 格式为 YYYY-MM-DD, 无数据时为 null
+}
@@ -0,0 +1,100 @@
+package danmuku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试用的 protobuf 编码
+func pbVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func pbField(b []byte, field, wire int) []byte {
+	return pbVarint(b, uint64(field<<3|wire))
+}
+
+func pbInt(b []byte, field int, v uint64) []byte {
+	return pbVarint(pbField(b, field, wireVarint), v)
+}
+
+func pbBytes(b []byte, field int, v []byte) []byte {
+	b = pbVarint(pbField(b, field, wireBytes), uint64(len(v)))
+	return append(b, v...)
+}
+
+func TestDecodeSegReply(t *testing.T) {
+	var elem []byte
+	elem = pbInt(elem, 1, 1234567890123)
+	elem = pbInt(elem, 2, 65000)
+	elem = pbInt(elem, 3, 5)
+	elem = pbInt(elem, 4, 25)
+	elem = pbInt(elem, 5, 0xFFFFFF)
+	elem = pbBytes(elem, 6, []byte("abcd1234"))
+	elem = pbBytes(elem, 7, []byte("前方高能"))
+	elem = pbInt(elem, 8, 1700000000)
+	elem = pbInt(elem, 9, 10)
+	elem = pbInt(elem, 11, 1)
+	elem = pbBytes(elem, 12, []byte("1234567890123"))
+	elem = pbBytes(elem, 99, []byte("unknown")) // 未知字段应被跳过
+
+	var reply []byte
+	reply = pbBytes(reply, 1, elem)
+	reply = pbInt(reply, 2, 0)
+
+	got, err := decodeSegReply(reply)
+	assert.NoError(t, err)
+	assert.Len(t, got.Elems, 1)
+
+	dm := got.Elems[0]
+	assert.Equal(t, int64(1234567890123), dm.ID)
+	assert.Equal(t, 65000, dm.Progress)
+	assert.Equal(t, 5, dm.Mode)
+	assert.Equal(t, 25, dm.FontSize)
+	assert.Equal(t, uint32(0xFFFFFF), dm.Color)
+	assert.Equal(t, "abcd1234", dm.MidHash)
+	assert.Equal(t, "前方高能", dm.Content)
+	assert.Equal(t, int64(1700000000), dm.Ctime)
+	assert.Equal(t, 10, dm.Weight)
+	assert.Equal(t, 1, dm.Pool)
+	assert.Equal(t, "1234567890123", dm.IDStr)
+	assert.Equal(t, 65.0, dm.Time())
+}
+
+func TestDecodeSegReplyTruncated(t *testing.T) {
+	_, err := decodeSegReply([]byte{0x0a, 0x05, 0x08})
+	assert.Error(t, err)
+}
+
+func TestParseXML(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?><i><chatserver>chat.bilibili.com</chatserver>` +
+		`<d p="12.5,1,25,16777215,1700000001,0,ff00aa,222,7">第二条</d>` +
+		`<d p="3.2,5,18,255,1700000000,1,ee00bb,111,3">第一条</d>` +
+		`<d p="1.005,1,25,16777215,1700000002,0,ff00aa,333">四舍五入</d>` +
+		`<d p="abc,1,25,16777215,1700000003,0,ff00aa,444,0">格式错误</d>` +
+		`<d p="5,1,25,16777215,1700000004,0,ff00aa,555,x">格式错误</d></i>`)
+
+	list, err := ParseXML(data)
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+	assert.Equal(t, "四舍五入", list[0].Content)
+	assert.Equal(t, 1005, list[0].Progress)
+	assert.Equal(t, 0, list[0].Weight)
+	assert.Equal(t, "第一条", list[1].Content)
+	assert.Equal(t, 3200, list[1].Progress)
+	assert.Equal(t, 5, list[1].Mode)
+	assert.Equal(t, uint32(255), list[1].Color)
+	assert.Equal(t, int64(111), list[1].ID)
+	assert.Equal(t, 3, list[1].Weight)
+}
+
+func TestSegmentCount(t *testing.T) {
+	assert.Equal(t, 1, SegmentCount(0))
+	assert.Equal(t, 1, SegmentCount(360000))
+	assert.Equal(t, 2, SegmentCount(360001))
+}
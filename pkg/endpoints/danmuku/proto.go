@@ -0,0 +1,189 @@
+package danmuku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// protobuf wire type
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("protobuf: unexpected end of data")
+
+// protoReader 极简的 protobuf 解码器, 只覆盖弹幕接口用到的类型
+type protoReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *protoReader) done() bool {
+	return r.pos >= len(r.buf)
+}
+
+func (r *protoReader) varint() (uint64, error) {
+	var v uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		if r.pos >= len(r.buf) {
+			return 0, errTruncated
+		}
+		b := r.buf[r.pos]
+		r.pos++
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+	return 0, errors.New("protobuf: varint overflow")
+}
+
+func (r *protoReader) key() (field int, wire int, err error) {
+	v, err := r.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(v >> 3), int(v & 7), nil
+}
+
+func (r *protoReader) bytes() ([]byte, error) {
+	n, err := r.varint()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(r.buf)-r.pos) < n {
+		return nil, errTruncated
+	}
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+func (r *protoReader) skip(wire int) error {
+	switch wire {
+	case wireVarint:
+		_, err := r.varint()
+		return err
+	case wireFixed64:
+		r.pos += 8
+	case wireBytes:
+		_, err := r.bytes()
+		return err
+	case wireFixed32:
+		r.pos += 4
+	default:
+		return fmt.Errorf("protobuf: unsupported wire type %d", wire)
+	}
+	if r.pos > len(r.buf) {
+		return errTruncated
+	}
+	return nil
+}
+
+// 解析 DmSegMobileReply
+//
+//	message DmSegMobileReply {
+//	  repeated DanmakuElem elems = 1;
+//	  int32 state = 2;
+//	}
+func decodeSegReply(buf []byte) (*SegmentReply, error) {
+	reply := &SegmentReply{}
+	r := &protoReader{buf: buf}
+	for !r.done() {
+		field, wire, err := r.key()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case field == 1 && wire == wireBytes:
+			b, err := r.bytes()
+			if err != nil {
+				return nil, err
+			}
+			dm, err := decodeElem(b)
+			if err != nil {
+				return nil, err
+			}
+			reply.Elems = append(reply.Elems, dm)
+		case field == 2 && wire == wireVarint:
+			v, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			reply.State = int(v)
+		default:
+			if err := r.skip(wire); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return reply, nil
+}
+
+// 解析 DanmakuElem
+func decodeElem(buf []byte) (Danmaku, error) {
+	var dm Danmaku
+	r := &protoReader{buf: buf}
+	for !r.done() {
+		field, wire, err := r.key()
+		if err != nil {
+			return dm, err
+		}
+
+		if wire == wireVarint {
+			v, err := r.varint()
+			if err != nil {
+				return dm, err
+			}
+			switch field {
+			case 1:
+				dm.ID = int64(v)
+			case 2:
+				dm.Progress = int(int32(v))
+			case 3:
+				dm.Mode = int(int32(v))
+			case 4:
+				dm.FontSize = int(int32(v))
+			case 5:
+				dm.Color = uint32(v)
+			case 8:
+				dm.Ctime = int64(v)
+			case 9:
+				dm.Weight = int(int32(v))
+			case 11:
+				dm.Pool = int(int32(v))
+			case 13:
+				dm.Attr = int(int32(v))
+			}
+			continue
+		}
+
+		if wire == wireBytes {
+			b, err := r.bytes()
+			if err != nil {
+				return dm, err
+			}
+			switch field {
+			case 6:
+				dm.MidHash = string(b)
+			case 7:
+				dm.Content = string(b)
+			case 10:
+				dm.Action = string(b)
+			case 12:
+				dm.IDStr = string(b)
+			case 22:
+				dm.Animation = string(b)
+			}
+			continue
+		}
+
+		if err := r.skip(wire); err != nil {
+			return dm, err
+		}
+	}
+	return dm, nil
+}
@@ -0,0 +1,434 @@
+package danmuku
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/video"
+)
+
+// 弹幕类型
+const (
+	ModeScroll  = 1 // 普通滚动弹幕, 2 3 同样视为滚动
+	ModeBottom  = 4 // 底部弹幕
+	ModeTop     = 5 // 顶部弹幕
+	ModeReverse = 6 // 逆向滚动弹幕
+)
+
+// RenderOptions 弹幕转 ASS 的参数, 零值字段使用默认值
+type RenderOptions struct {
+	Width          int              // 画布宽度, 默认 1920
+	Height         int              // 画布高度, 默认 1080
+	FontName       string           // 字体, 默认 Microsoft YaHei
+	FontScale      float64          // 字号缩放, 弹幕字号 25 对应 25*FontScale 像素, 默认按高度 1080 时为 1.6
+	ScrollDuration float64          // 滚动弹幕持续时间, 单位为秒, 默认 8
+	StaticDuration float64          // 顶部/底部弹幕持续时间, 单位为秒, 默认 5
+	Opacity        float64          // 不透明度, 0-1, 默认 0.8
+	Outline        float64          // 描边宽度, 默认 1.5
+	DisplayArea    float64          // 滚动弹幕可用的屏幕高度比例, 0-1, 默认 1
+	MaxOnScreen    int              // 同屏弹幕上限, 0 表示不限制
+	AllowOverlap   bool             // 找不到空闲轨道时是否允许重叠显示, 默认丢弃
+	MinWeight      int              // 最低权重, 低于此值的弹幕被屏蔽
+	Keywords       []string         // 关键词屏蔽
+	Patterns       []*regexp.Regexp // 正则屏蔽
+	BlockModes     []int            // 屏蔽的弹幕类型, 如 ModeTop
+}
+
+// RenderStats 渲染结果统计
+type RenderStats struct {
+	Rendered int // 输出的弹幕数
+	Blocked  int // 被屏蔽规则过滤的弹幕数
+	Dropped  int // 因密度或无空闲轨道而丢弃的弹幕数
+	Skipped  int // 不支持的弹幕类型 (高级/代码/BAS 弹幕)
+}
+
+// ScaleTo 按视频分辨率设置画布, 处理宽高对换
+func (o *RenderOptions) ScaleTo(dim video.Dimension) {
+	w, h := dim.Width, dim.Height
+	if dim.Rotate == 1 {
+		w, h = h, w
+	}
+	if w <= 0 || h <= 0 {
+		return
+	}
+	o.Width, o.Height = w, h
+	o.FontScale = 1.6 * float64(h) / 1080
+}
+
+// Blocked 判断弹幕是否被屏蔽规则过滤
+func (o *RenderOptions) Blocked(dm Danmaku) bool {
+	if dm.Weight < o.MinWeight {
+		return true
+	}
+	for _, m := range o.BlockModes {
+		if normalizeMode(dm.Mode) == m {
+			return true
+		}
+	}
+	for _, k := range o.Keywords {
+		if k != "" && strings.Contains(dm.Content, k) {
+			return true
+		}
+	}
+	for _, p := range o.Patterns {
+		if p != nil && p.MatchString(dm.Content) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o RenderOptions) withDefaults() RenderOptions {
+	if o.Width <= 0 {
+		o.Width = 1920
+	}
+	if o.Height <= 0 {
+		o.Height = 1080
+	}
+	if o.FontName == "" {
+		o.FontName = "Microsoft YaHei"
+	}
+	if o.FontScale <= 0 {
+		o.FontScale = 1.6 * float64(o.Height) / 1080
+	}
+	if o.ScrollDuration <= 0 {
+		o.ScrollDuration = 8
+	}
+	if o.StaticDuration <= 0 {
+		o.StaticDuration = 5
+	}
+	if o.Opacity <= 0 || o.Opacity > 1 {
+		o.Opacity = 0.8
+	}
+	if o.Outline <= 0 {
+		o.Outline = 1.5
+	}
+	if o.DisplayArea <= 0 || o.DisplayArea > 1 {
+		o.DisplayArea = 1
+	}
+	return o
+}
+
+// 将弹幕渲染为 ASS 字幕
+//
+// Parameters:
+//   - w (io.Writer): 输出
+//   - list ([]Danmaku): 弹幕列表, 需按 Progress 排序
+//   - opts (*RenderOptions): 渲染参数, 为 nil 时使用默认值
+//
+// 备注：
+//   - 滚动弹幕按轨道分配, 保证同一轨道内前后弹幕不会追尾
+//   - 顶部/底部弹幕按轨道占用时间分配
+func RenderASS(w io.Writer, list []Danmaku, opts *RenderOptions) (RenderStats, error) {
+	o := RenderOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o = o.withDefaults()
+
+	bw := bufio.NewWriter(w)
+	writeASSHeader(bw, o)
+
+	r := newRenderer(o)
+	var stats RenderStats
+	for _, dm := range list {
+		mode := normalizeMode(dm.Mode)
+		if mode == 0 {
+			stats.Skipped++
+			continue
+		}
+		if o.Blocked(dm) {
+			stats.Blocked++
+			continue
+		}
+		line, ok := r.place(dm, mode)
+		if !ok {
+			stats.Dropped++
+			continue
+		}
+		bw.WriteString(line)
+		stats.Rendered++
+	}
+	return stats, bw.Flush()
+}
+
+func writeASSHeader(w io.Writer, o RenderOptions) {
+	alpha := int(math.Round(255 * (1 - o.Opacity)))
+	fontSize := int(math.Round(25 * o.FontScale))
+
+	fmt.Fprintf(w, "[Script Info]\n")
+	fmt.Fprintf(w, "ScriptType: v4.00+\n")
+	fmt.Fprintf(w, "PlayResX: %d\n", o.Width)
+	fmt.Fprintf(w, "PlayResY: %d\n", o.Height)
+	fmt.Fprintf(w, "Aspect Ratio: %d:%d\n", o.Width, o.Height)
+	fmt.Fprintf(w, "Collisions: Normal\n")
+	fmt.Fprintf(w, "WrapStyle: 2\n")
+	fmt.Fprintf(w, "ScaledBorderAndShadow: yes\n\n")
+
+	fmt.Fprintf(w, "[V4+ Styles]\n")
+	fmt.Fprintf(w, "Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	fmt.Fprintf(w, "Style: Danmaku,%s,%d,&H%02XFFFFFF,&H%02XFFFFFF,&H%02X000000,&H%02X000000,0,0,0,0,100,100,0,0,1,%.1f,0,7,0,0,0,1\n\n",
+		o.FontName, fontSize, alpha, alpha, alpha, alpha, o.Outline)
+
+	fmt.Fprintf(w, "[Events]\n")
+	fmt.Fprintf(w, "Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+}
+
+// 1 2 3 均为普通滚动弹幕, 不支持的类型返回 0
+func normalizeMode(mode int) int {
+	switch mode {
+	case 1, 2, 3:
+		return ModeScroll
+	case ModeBottom, ModeTop, ModeReverse:
+		return mode
+	}
+	return 0
+}
+
+// 单条轨道的占用情况
+type lane struct {
+	start float64 // 占用弹幕的出现时间
+	end   float64 // 占用弹幕的消失时间
+	width float64 // 占用弹幕的宽度 (仅滚动弹幕)
+	used  bool
+}
+
+type renderer struct {
+	o          RenderOptions
+	laneHeight float64
+	scroll     []lane
+	reverse    []lane
+	top        []lane
+	bottom     []lane
+	active     []float64 // 同屏弹幕的消失时间
+}
+
+func newRenderer(o RenderOptions) *renderer {
+	// 以标准字号作为轨道高度, 大号弹幕占用多条轨道
+	laneHeight := math.Ceil(25*o.FontScale) + 2
+	total := int(float64(o.Height) / laneHeight)
+	scrollLanes := int(float64(o.Height) * o.DisplayArea / laneHeight)
+	if total < 1 {
+		total = 1
+	}
+	if scrollLanes < 1 {
+		scrollLanes = 1
+	}
+	return &renderer{
+		o:          o,
+		laneHeight: laneHeight,
+		scroll:     make([]lane, scrollLanes),
+		reverse:    make([]lane, scrollLanes),
+		top:        make([]lane, total),
+		bottom:     make([]lane, total),
+	}
+}
+
+// 为弹幕分配位置并生成 Dialogue 行
+func (r *renderer) place(dm Danmaku, mode int) (string, bool) {
+	start := dm.Time()
+	fontSize := float64(dm.FontSize)
+	if fontSize <= 0 {
+		fontSize = 25
+	}
+	fontPx := fontSize * r.o.FontScale
+	width := textWidth(dm.Content, fontPx)
+	span := int(math.Ceil((fontPx + 2) / r.laneHeight))
+
+	duration := r.o.StaticDuration
+	if mode == ModeScroll || mode == ModeReverse {
+		duration = r.o.ScrollDuration
+	}
+	end := start + duration
+
+	if !r.admit(start) {
+		return "", false
+	}
+
+	var idx int
+	switch mode {
+	case ModeScroll:
+		idx = r.findScrollLane(r.scroll, start, width, span)
+	case ModeReverse:
+		idx = r.findScrollLane(r.reverse, start, width, span)
+	case ModeTop:
+		idx = findStaticLane(r.top, start, span)
+	case ModeBottom:
+		idx = findStaticLane(r.bottom, start, span)
+	}
+	if idx < 0 {
+		if !r.o.AllowOverlap {
+			return "", false
+		}
+		idx = 0
+	}
+
+	occupy := lane{start: start, end: end, width: width, used: true}
+	W := float64(r.o.Width)
+	H := float64(r.o.Height)
+	var pos string
+	switch mode {
+	case ModeScroll:
+		fill(r.scroll, idx, span, occupy)
+		y := float64(idx) * r.laneHeight
+		pos = fmt.Sprintf(`\move(%.0f,%.0f,%.0f,%.0f)`, W, y, -width, y)
+	case ModeReverse:
+		fill(r.reverse, idx, span, occupy)
+		y := float64(idx) * r.laneHeight
+		pos = fmt.Sprintf(`\move(%.0f,%.0f,%.0f,%.0f)`, -width, y, W, y)
+	case ModeTop:
+		fill(r.top, idx, span, occupy)
+		y := float64(idx) * r.laneHeight
+		pos = fmt.Sprintf(`\an8\pos(%.0f,%.0f)`, W/2, y)
+	case ModeBottom:
+		fill(r.bottom, idx, span, occupy)
+		y := H - float64(idx)*r.laneHeight
+		pos = fmt.Sprintf(`\an2\pos(%.0f,%.0f)`, W/2, y)
+	}
+	r.active = append(r.active, end)
+
+	var tags strings.Builder
+	tags.WriteString("{")
+	tags.WriteString(pos)
+	if dm.FontSize != 25 && dm.FontSize > 0 {
+		fmt.Fprintf(&tags, `\fs%.0f`, fontPx)
+	}
+	color := dm.Color & 0xFFFFFF
+	if color != 0xFFFFFF {
+		fmt.Fprintf(&tags, `\c&H%02X%02X%02X&`, color&0xFF, color>>8&0xFF, color>>16)
+		if isDark(color) {
+			// 深色弹幕使用浅色描边, 保证可读性
+			tags.WriteString(`\3c&HFFFFFF&`)
+		}
+	}
+	tags.WriteString("}")
+
+	return fmt.Sprintf("Dialogue: %d,%s,%s,Danmaku,,0,0,0,,%s%s\n",
+		layerOf(mode),
+		formatTime(start),
+		formatTime(end),
+		tags.String(),
+		escapeText(dm.Content)), true
+}
+
+// 同屏数量限制
+func (r *renderer) admit(t float64) bool {
+	if r.o.MaxOnScreen <= 0 {
+		return true
+	}
+	alive := r.active[:0]
+	for _, end := range r.active {
+		if end > t {
+			alive = append(alive, end)
+		}
+	}
+	r.active = alive
+	return len(r.active) < r.o.MaxOnScreen
+}
+
+// 查找可用的滚动轨道
+//
+// 同一轨道内, 新弹幕需满足:
+//   - 前一条弹幕的尾部已完全进入屏幕
+//   - 新弹幕在前一条离开屏幕前不会追上它
+func (r *renderer) findScrollLane(lanes []lane, t, width float64, span int) int {
+	W := float64(r.o.Width)
+	D := r.o.ScrollDuration
+	for i := 0; i+span <= len(lanes); i++ {
+		ok := true
+		for j := i; j < i+span; j++ {
+			l := lanes[j]
+			if !l.used || l.end <= t {
+				continue
+			}
+			prevSpeed := (W + l.width) / D
+			newSpeed := (W + width) / D
+			// 前一条尾部进入屏幕的时间
+			if l.start+l.width/prevSpeed > t {
+				ok = false
+				break
+			}
+			// 新弹幕头部到达左边缘的时间不能早于前一条尾部离开
+			if t+W/newSpeed < l.end {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func findStaticLane(lanes []lane, t float64, span int) int {
+	for i := 0; i+span <= len(lanes); i++ {
+		ok := true
+		for j := i; j < i+span; j++ {
+			if lanes[j].used && lanes[j].end > t {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func fill(lanes []lane, idx, span int, l lane) {
+	for j := idx; j < idx+span && j < len(lanes); j++ {
+		lanes[j] = l
+	}
+}
+
+// 估算文本宽度, 全角字符按字号计, 半角按一半计
+func textWidth(s string, fontPx float64) float64 {
+	var w float64
+	for _, c := range s {
+		if c < 0x2E80 {
+			w += fontPx * 0.5
+		} else {
+			w += fontPx
+		}
+	}
+	return w
+}
+
+func isDark(color uint32) bool {
+	r := float64(color >> 16 & 0xFF)
+	g := float64(color >> 8 & 0xFF)
+	b := float64(color & 0xFF)
+	return 0.299*r+0.587*g+0.114*b < 48
+}
+
+// 顶部/底部弹幕置于滚动弹幕上层
+func layerOf(mode int) int {
+	if mode == ModeTop || mode == ModeBottom {
+		return 1
+	}
+	return 0
+}
+
+func formatTime(seconds float64) string {
+	cs := int(seconds*100 + 0.5)
+	if cs < 0 {
+		cs = 0
+	}
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
+
+func escapeText(s string) string {
+	// 反斜杠后插入零宽空格, 避免被解析为 ASS 转义
+	s = strings.ReplaceAll(s, `\`, "\\\u200b")
+	s = strings.ReplaceAll(s, "{", `\{`)
+	s = strings.ReplaceAll(s, "}", `\}`)
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", `\N`)
+	return s
+}
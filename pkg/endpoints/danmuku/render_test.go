@@ -0,0 +1,64 @@
+package danmuku
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/video"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderASS(t *testing.T) {
+	list := []Danmaku{
+		{Progress: 0, Mode: 1, FontSize: 25, Color: 0xFFFFFF, Content: "第一条"},
+		{Progress: 0, Mode: 1, FontSize: 25, Color: 0xFF0000, Content: "第二条"},
+		{Progress: 1000, Mode: 5, FontSize: 25, Color: 0xFFFFFF, Content: "顶部"},
+		{Progress: 1000, Mode: 4, FontSize: 36, Color: 0x000000, Content: "底部"},
+		{Progress: 2000, Mode: 1, FontSize: 25, Content: "广告 加群"},
+		{Progress: 2000, Mode: 1, FontSize: 25, Content: "AV12345"},
+		{Progress: 3000, Mode: 7, FontSize: 25, Content: "高级弹幕"},
+	}
+
+	var sb strings.Builder
+	stats, err := RenderASS(&sb, list, &RenderOptions{
+		Keywords: []string{"广告"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`(?i)^av\d+$`)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, RenderStats{Rendered: 4, Blocked: 2, Skipped: 1}, stats)
+
+	ass := sb.String()
+	assert.Contains(t, ass, "PlayResX: 1920")
+	// 同一时间的两条滚动弹幕分配到不同轨道
+	assert.Contains(t, ass, `{\move(1920,0,-120,0)}第一条`)
+	assert.Contains(t, ass, `{\move(1920,42,-120,42)\c&H0000FF&}第二条`)
+	assert.Contains(t, ass, `Dialogue: 1,0:00:01.00,0:00:06.00,Danmaku,,0,0,0,,{\an8\pos(960,0)}顶部`)
+	assert.Contains(t, ass, `\an2\pos(960,1080)\fs58\c&H000000&\3c&HFFFFFF&}底部`)
+}
+
+func TestRenderASSDensity(t *testing.T) {
+	var list []Danmaku
+	for i := 0; i < 100; i++ {
+		list = append(list, Danmaku{Progress: 0, Mode: 1, FontSize: 25, Content: "刷屏"})
+	}
+
+	var sb strings.Builder
+	stats, err := RenderASS(&sb, list, &RenderOptions{MaxOnScreen: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, stats.Rendered)
+	assert.Equal(t, 90, stats.Dropped)
+
+	// 轨道用尽后丢弃
+	stats, err = RenderASS(&sb, list, &RenderOptions{DisplayArea: 0.25})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, stats.Rendered)
+}
+
+func TestScaleTo(t *testing.T) {
+	o := &RenderOptions{}
+	o.ScaleTo(video.Dimension{Width: 1080, Height: 1920, Rotate: 1})
+	assert.Equal(t, 1920, o.Width)
+	assert.Equal(t, 1080, o.Height)
+	assert.InDelta(t, 1.6, o.FontScale, 1e-9)
+}
@@ -1 +1,383 @@
 package dynamic
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 富文本节点类型 (发布动态)
+const (
+	ContentText  = 1 // 文字, 旧式话题以 "#话题#" 形式写在文字中
+	ContentAt    = 2 // @用户, BizID 为用户 mid
+	ContentEmote = 9 // 表情, RawText 为表情文本, 如 "[doge]"
+)
+
+// 发布场景
+const (
+	sceneText    = 1 // 纯文字
+	sceneDraw    = 2 // 带图
+	sceneForward = 4 // 转发
+)
+
+// DynTopic 发布动态时关联的话题
+type DynTopic struct {
+	ID   int64  `json:"id"`   // 话题 id
+	Name string `json:"name"` // 话题名
+}
+
+// ContentNode 发布动态时的富文本节点
+type ContentNode struct {
+	RawText string `json:"raw_text"` // 文本
+	Type    int    `json:"type"`     // 节点类型, 见 ContentText 等
+	BizID   string `json:"biz_id"`   // 关联 id
+}
+
+// RichText 动态正文构造器
+//
+// 用法:
+//
+//	content := dynamic.NewRichText().
+//		Text("新视频发布啦 ").
+//		At(2, "碧诗").
+//		Text(" ").
+//		Emote("[doge]").
+//		Topic(1234, "日常")
+type RichText struct {
+	nodes []ContentNode
+	topic *DynTopic
+}
+
+// 创建空的动态正文
+func NewRichText() *RichText {
+	return &RichText{}
+}
+
+// Text 追加文字, 与前一个文字节点合并
+func (r *RichText) Text(s string) *RichText {
+	if s == "" {
+		return r
+	}
+	if n := len(r.nodes); n > 0 && r.nodes[n-1].Type == ContentText {
+		r.nodes[n-1].RawText += s
+		return r
+	}
+	r.nodes = append(r.nodes, ContentNode{RawText: s, Type: ContentText})
+	return r
+}
+
+// At 追加 @用户
+func (r *RichText) At(mid int, name string) *RichText {
+	r.nodes = append(r.nodes, ContentNode{RawText: "@" + name, Type: ContentAt, BizID: fmt.Sprintf("%d", mid)})
+	return r
+}
+
+// Emote 追加表情, 如 "[doge]"
+func (r *RichText) Emote(text string) *RichText {
+	r.nodes = append(r.nodes, ContentNode{RawText: text, Type: ContentEmote})
+	return r
+}
+
+// Topic 关联话题, 发布时作为 dyn_req.topic 提交, 每条动态只能关联一个话题, 重复调用时以最后一次为准
+//
+// Parameters:
+//   - id (int64): 话题 id
+//   - name (string): 话题名
+func (r *RichText) Topic(id int64, name string) *RichText {
+	r.topic = &DynTopic{ID: id, Name: name}
+	return r
+}
+
+// Hashtag 以 "#话题#" 形式在文字中追加旧式话题, 如 Hashtag("日常") 为 "#日常#"
+func (r *RichText) Hashtag(name string) *RichText {
+	return r.Text("#" + name + "#")
+}
+
+// AttachedTopic 返回关联的话题, 未关联时为 nil
+func (r *RichText) AttachedTopic() *DynTopic {
+	if r == nil || r.topic == nil {
+		return nil
+	}
+	t := *r.topic
+	return &t
+}
+
+// Contents 返回富文本节点
+func (r *RichText) Contents() []ContentNode {
+	if r == nil {
+		return []ContentNode{}
+	}
+	return append([]ContentNode{}, r.nodes...)
+}
+
+// String 返回纯文本
+func (r *RichText) String() string {
+	if r == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, n := range r.nodes {
+		sb.WriteString(n.RawText)
+	}
+	return sb.String()
+}
+
+// Picture 动态图片, 由 UploadImage 得到
+type Picture struct {
+	ImgSrc    string  `json:"img_src"`    // 图片 url
+	ImgWidth  int     `json:"img_width"`  // 宽度
+	ImgHeight int     `json:"img_height"` // 高度
+	ImgSize   float64 `json:"img_size"`   // 大小, 单位为 KB
+}
+
+// 上传动态图片
+//
+// Parameters:
+//   - r (io.Reader): 图片内容
+//   - filename (string): 文件名, 如 "a.png"
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+//
+// 备注：
+//   - 上传结果也可用于评论图片
+func (d *Dynamic) UploadImage(r io.Reader, filename string) (*UploadImageResponse, error) {
+	baseURL := "https://api.bilibili.com/x/dynamic/feed/draw/upload_bfs"
+
+	resp, err := d.client.HTTPClient.R().
+		SetFileReader("file_up", filename, r).
+		SetMultipartFormData(map[string]string{
+			"category": "daily",
+			"biz":      "new_dyn",
+			"csrf":     d.client.CSRF,
+		}).
+		SetHeader("Referer", "https://t.bilibili.com").
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&UploadImageResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*UploadImageResponse), nil
+}
+
+// 发布纯文字动态
+//
+// Parameters:
+//   - content (*RichText): 动态正文
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (d *Dynamic) CreateText(content *RichText) (*CreateResponse, error) {
+	return d.create(d.newCreateRequest(sceneText, content, nil, time.Time{}))
+}
+
+// 发布带图动态
+//
+// Parameters:
+//   - content (*RichText): 动态正文
+//   - pics ([]Picture): 图片, 由 UploadImage 得到, 最多9张
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (d *Dynamic) CreateDraw(content *RichText, pics []Picture) (*CreateResponse, error) {
+	return d.create(d.newCreateRequest(sceneDraw, content, pics, time.Time{}))
+}
+
+// 定时发布动态
+//
+// Parameters:
+//   - content (*RichText): 动态正文
+//   - pics ([]Picture): 图片 (可选), 为空时发布纯文字动态
+//   - at (time.Time): 发布时间, 需晚于当前时间
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (d *Dynamic) Schedule(content *RichText, pics []Picture, at time.Time) (*CreateResponse, error) {
+	scene := sceneText
+	if len(pics) > 0 {
+		scene = sceneDraw
+	}
+	return d.create(d.newCreateRequest(scene, content, pics, at))
+}
+
+// 转发动态
+//
+// Parameters:
+//   - dynID (string): 被转发的动态 id
+//   - content (*RichText): 转发评论 (可选)
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (d *Dynamic) Repost(dynID string, content *RichText) (*CreateResponse, error) {
+	req := d.newCreateRequest(sceneForward, content, nil, time.Time{})
+	req.WebRepostSrc = &repostSrc{DynIDStr: dynID}
+	return d.create(req)
+}
+
+// 转发视频
+//
+// Parameters:
+//   - aid (int): 视频 avid
+//   - content (*RichText): 转发评论 (可选)
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (d *Dynamic) RepostVideo(aid int, content *RichText) (*CreateResponse, error) {
+	req := d.newCreateRequest(sceneForward, content, nil, time.Time{})
+	req.WebRepostSrc = &repostSrc{RevsID: &revsID{DynType: 8, Rid: fmt.Sprintf("%d", aid)}}
+	return d.create(req)
+}
+
+// 删除动态
+//
+// Parameters:
+//   - dynID (string): 动态 id
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 需要 csrf
+func (d *Dynamic) Delete(dynID string) (*misc.BaseResponse, error) {
+	baseURL := "https://api.bilibili.com/x/dynamic/feed/operate/remove"
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(map[string]string{
+			"platform": "web",
+			"csrf":     d.client.CSRF,
+		}).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Referer", "https://t.bilibili.com").
+		SetBody(map[string]string{"dyn_id_str": dynID}).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
+
+// 发布动态的请求体
+type createRequest struct {
+	DynReq       dynReq     `json:"dyn_req"`
+	WebRepostSrc *repostSrc `json:"web_repost_src,omitempty"`
+}
+
+type dynReq struct {
+	Content struct {
+		Contents []ContentNode `json:"contents"`
+	} `json:"content"`
+	Scene    int       `json:"scene"`
+	Pics     []Picture `json:"pics,omitempty"`
+	UploadID string    `json:"upload_id"`
+	Meta     struct {
+		AppMeta struct {
+			From    string `json:"from"`
+			MobiApp string `json:"mobi_app"`
+		} `json:"app_meta"`
+	} `json:"meta"`
+	Option *dynOption `json:"option,omitempty"`
+	Topic  *DynTopic  `json:"topic,omitempty"`
+}
+
+type dynOption struct {
+	TimerPubTime int64 `json:"timer_pub_time"` // 定时发布时间
+}
+
+type repostSrc struct {
+	DynIDStr string  `json:"dyn_id_str,omitempty"`
+	RevsID   *revsID `json:"revs_id,omitempty"`
+}
+
+type revsID struct {
+	DynType int    `json:"dyn_type"` // 8: 视频
+	Rid     string `json:"rid"`
+}
+
+func (d *Dynamic) newCreateRequest(scene int, content *RichText, pics []Picture, at time.Time) *createRequest {
+	req := &createRequest{}
+	req.DynReq.Scene = scene
+	req.DynReq.Content.Contents = content.Contents()
+	req.DynReq.Topic = content.AttachedTopic()
+	req.DynReq.Pics = pics
+	// 格式为 mid_时间戳_随机数, 用于服务端去重
+	req.DynReq.UploadID = fmt.Sprintf("%d_%d_%d", d.client.DedeUserID, time.Now().Unix(), rand.Intn(9000)+1000)
+	req.DynReq.Meta.AppMeta.From = "create.dynamic.web"
+	req.DynReq.Meta.AppMeta.MobiApp = "web"
+	if !at.IsZero() {
+		req.DynReq.Option = &dynOption{TimerPubTime: at.Unix()}
+	}
+	return req
+}
+
+func (d *Dynamic) create(req *createRequest) (*CreateResponse, error) {
+	baseURL := "https://api.bilibili.com/x/dynamic/feed/create/dyn"
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(map[string]string{
+			"platform": "web",
+			"csrf":     d.client.CSRF,
+		}).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Referer", "https://t.bilibili.com").
+		SetBody(req).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&CreateResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*CreateResponse), nil
+}
+
+// UploadImageResponse 上传动态图片结果
+type UploadImageResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -111表示csrf校验失败, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		ImageURL    string  `json:"image_url"`    // 图片 url
+		ImageWidth  int     `json:"image_width"`  // 宽度
+		ImageHeight int     `json:"image_height"` // 高度
+		ImgSize     float64 `json:"img_size"`     // 大小, 单位为 KB
+	} `json:"data"` // 数据本体
+}
+
+// Picture 转换为发布动态所需的图片
+func (r *UploadImageResponse) Picture() Picture {
+	return Picture{
+		ImgSrc:    r.Data.ImageURL,
+		ImgWidth:  r.Data.ImageWidth,
+		ImgHeight: r.Data.ImageHeight,
+		ImgSize:   r.Data.ImgSize,
+	}
+}
+
+// CreateResponse 发布动态结果
+type CreateResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -111表示csrf校验失败, -400表示请求错误, 4126125表示定时发布时间不合法
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		DynID    int64  `json:"dyn_id"`     // 动态 id
+		DynIDStr string `json:"dyn_id_str"` // 动态 id 字符串形式
+		DynType  int    `json:"dyn_type"`   // 动态类型
+		DynRid   int64  `json:"dyn_rid"`    // 关联内容 id
+	} `json:"data"` // 数据本体
+}
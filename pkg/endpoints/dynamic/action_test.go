@@ -0,0 +1,95 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/client"
+	"github.com/Yuelioi/bilibili/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRichText(t *testing.T) {
+	content := NewRichText().
+		Text("新视频发布啦").
+		Text(" ").
+		At(2, "碧诗").
+		Text(" ").
+		Hashtag("日常").
+		Emote("[doge]").
+		Topic(1234, "日常")
+
+	assert.Equal(t, []ContentNode{
+		{RawText: "新视频发布啦 ", Type: ContentText},
+		{RawText: "@碧诗", Type: ContentAt, BizID: "2"},
+		{RawText: " #日常#", Type: ContentText},
+		{RawText: "[doge]", Type: ContentEmote},
+	}, content.Contents())
+	assert.Equal(t, "新视频发布啦 @碧诗 #日常#[doge]", content.String())
+
+	assert.Equal(t, &DynTopic{ID: 1234, Name: "日常"}, content.AttachedTopic())
+
+	var empty *RichText
+	assert.Equal(t, []ContentNode{}, empty.Contents())
+	assert.Nil(t, empty.AttachedTopic())
+}
+
+func TestCreateRequest(t *testing.T) {
+	d := New(&client.Client{DedeUserID: 7})
+	at := time.Unix(1700003600, 0)
+	pics := []Picture{{ImgSrc: "http://i0.hdslb.com/a.jpg", ImgWidth: 10, ImgHeight: 20, ImgSize: 1.5}}
+
+	req := d.newCreateRequest(sceneDraw, NewRichText().Text("hi"), pics, at)
+	b, err := json.Marshal(req)
+	assert.NoError(t, err)
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b, &body))
+	dyn := body["dyn_req"].(map[string]interface{})
+	assert.Equal(t, 2.0, dyn["scene"])
+	assert.Equal(t, 1700003600.0, dyn["option"].(map[string]interface{})["timer_pub_time"])
+	assert.Equal(t, "http://i0.hdslb.com/a.jpg", dyn["pics"].([]interface{})[0].(map[string]interface{})["img_src"])
+	assert.True(t, strings.HasPrefix(dyn["upload_id"].(string), "7_"))
+	assert.NotContains(t, body, "web_repost_src")
+
+	req = d.newCreateRequest(sceneForward, nil, nil, time.Time{})
+	req.WebRepostSrc = &repostSrc{RevsID: &revsID{DynType: 8, Rid: "170001"}}
+	b, err = json.Marshal(req)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"contents":[]`)
+	assert.Contains(t, string(b), `"web_repost_src":{"revs_id":{"dyn_type":8,"rid":"170001"}}`)
+	assert.NotContains(t, string(b), `"option"`)
+	assert.NotContains(t, string(b), `"pics"`)
+}
+
+func TestCreateTopicBody(t *testing.T) {
+	c, rt := tests.NewRecordClient()
+	d := New(c)
+
+	_, err := d.CreateText(NewRichText().Text("打卡").Topic(1234, "日常"))
+	assert.NoError(t, err)
+	req := rt.Requests[0]
+	assert.Equal(t, "/x/dynamic/feed/create/dyn", req.URL.Path)
+	assert.Equal(t, "token", req.URL.Query().Get("csrf"))
+
+	var body struct {
+		DynReq struct {
+			Content struct {
+				Contents []ContentNode `json:"contents"`
+			} `json:"content"`
+			Scene int       `json:"scene"`
+			Topic *DynTopic `json:"topic"`
+		} `json:"dyn_req"`
+	}
+	assert.NoError(t, json.Unmarshal(rt.Bodies[0], &body))
+	assert.Equal(t, sceneText, body.DynReq.Scene)
+	assert.Equal(t, &DynTopic{ID: 1234, Name: "日常"}, body.DynReq.Topic)
+	assert.Equal(t, []ContentNode{{RawText: "打卡", Type: ContentText}}, body.DynReq.Content.Contents)
+
+	// 未关联话题时不提交 topic
+	_, err = d.CreateText(NewRichText().Text("打卡"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(rt.Bodies[1]), `"topic"`)
+}
@@ -0,0 +1,11 @@
+package dynamic
+
+import "github.com/Yuelioi/bilibili/pkg/client"
+
+type Dynamic struct {
+	client *client.Client
+}
+
+func New(client *client.Client) *Dynamic {
+	return &Dynamic{client}
+}
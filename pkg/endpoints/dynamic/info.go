@@ -0,0 +1,252 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 动态列表筛选类型
+const (
+	FeedAll     = "all"     // 全部
+	FeedVideo   = "video"   // 视频投稿
+	FeedPGC     = "pgc"     // 追番追剧
+	FeedArticle = "article" // 专栏
+)
+
+// 获取全部动态列表 (登录用户的关注动态)
+//
+// Parameters:
+//   - typ (string): 筛选类型, 见 FeedAll 等, 默认为 FeedAll
+//   - offset (string): 翻页游标, 首页为空, 之后为上一页的 Offset
+//   - updateBaseline (string): 更新基线, 为上次首页返回的 UpdateBaseline (可选), 用于统计 UpdateNum
+//   - page (int): 页码, 默认为1
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+func (d *Dynamic) Feed(typ, offset, updateBaseline string, page int) (*FeedResponse, error) {
+	baseURL := "https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/all"
+
+	if typ == "" {
+		typ = FeedAll
+	}
+	if page == 0 {
+		page = 1
+	}
+
+	formData := map[string]string{
+		"type":            typ,
+		"offset":          offset,
+		"update_baseline": updateBaseline,
+		"page":            fmt.Sprintf("%d", page),
+		"timezone_offset": "-480",
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetHeader("User-Agent", d.client.UserAgent).
+		SetHeader("Referer", "https://t.bilibili.com").
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&FeedResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*FeedResponse), nil
+}
+
+// 遍历全部动态列表
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - typ (string): 筛选类型, 见 FeedAll 等
+//   - offset (string): 起始游标, 为空时从首页开始
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (d *Dynamic) FeedIter(ctx context.Context, typ, offset string, maxItems int) *misc.CursorPager[Item] {
+	page := 0
+	return misc.NewCursorPager(ctx, offset, maxItems, func(cursor string) ([]Item, string, bool, error) {
+		page++
+		resp, err := d.Feed(typ, cursor, "", page)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if resp.Code != 0 {
+			return nil, "", false, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Items, resp.Data.Offset, !resp.Data.HasMore, nil
+	})
+}
+
+// 获取新动态数
+//
+// Parameters:
+//   - typ (string): 筛选类型, 见 FeedAll 等, 默认为 FeedAll
+//   - updateBaseline (string): 更新基线, 为上次 Feed 首页返回的 UpdateBaseline
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//
+// 备注：
+//   - 仅返回数量, 比直接请求 Feed 开销小, 适合轮询
+func (d *Dynamic) UpdateNum(typ, updateBaseline string) (*UpdateNumResponse, error) {
+	baseURL := "https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/all/update"
+
+	if typ == "" {
+		typ = FeedAll
+	}
+
+	formData := map[string]string{
+		"type":            typ,
+		"update_baseline": updateBaseline,
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetHeader("User-Agent", d.client.UserAgent).
+		SetHeader("Referer", "https://t.bilibili.com").
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&UpdateNumResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*UpdateNumResponse), nil
+}
+
+// 获取用户空间动态
+//
+// Parameters:
+//   - hostMid (int): 目标用户 mid
+//   - offset (string): 翻页游标, 首页为空, 之后为上一页的 Offset
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//   - 鉴权方式：Wbi 签名
+func (d *Dynamic) Space(hostMid int, offset string) (*FeedResponse, error) {
+	baseURL := "https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/space"
+
+	params := url.Values{}
+	params.Set("host_mid", fmt.Sprintf("%d", hostMid))
+	params.Set("offset", offset)
+	params.Set("timezone_offset", "-480")
+	params.Set("platform", "web")
+	params.Set("web_location", "333.1387")
+
+	newUrl, err := login.New(d.client).SignAndGenerateURL(baseURL + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetHeader("User-Agent", d.client.UserAgent).
+		SetHeader("Referer", fmt.Sprintf("https://space.bilibili.com/%d/dynamic", hostMid)).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&FeedResponse{}).
+		Get(newUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*FeedResponse), nil
+}
+
+// 遍历用户空间动态
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - hostMid (int): 目标用户 mid
+//   - offset (string): 起始游标, 为空时从首页开始
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (d *Dynamic) SpaceIter(ctx context.Context, hostMid int, offset string, maxItems int) *misc.CursorPager[Item] {
+	return misc.NewCursorPager(ctx, offset, maxItems, func(cursor string) ([]Item, string, bool, error) {
+		resp, err := d.Space(hostMid, cursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if resp.Code != 0 {
+			return nil, "", false, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Items, resp.Data.Offset, !resp.Data.HasMore, nil
+	})
+}
+
+// 获取动态详情
+//
+// Parameters:
+//   - id (string): 动态 id
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 查看仅粉丝可见等动态时必要
+func (d *Dynamic) Detail(id string) (*DetailResponse, error) {
+	baseURL := "https://api.bilibili.com/x/polymer/web-dynamic/v1/detail"
+
+	formData := map[string]string{
+		"id":              id,
+		"timezone_offset": "-480",
+	}
+
+	resp, err := d.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetHeader("User-Agent", d.client.UserAgent).
+		SetHeader("Referer", "https://t.bilibili.com").
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: d.client.SESSDATA,
+		}).
+		SetResult(&DetailResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*DetailResponse), nil
+}
+
+// FeedResponse 动态列表
+type FeedResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -352表示风控校验失败, 4101128表示参数错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		HasMore        bool   `json:"has_more"`        // 是否有更多
+		Items          []Item `json:"items"`           // 动态列表
+		Offset         string `json:"offset"`          // 下一页游标
+		UpdateBaseline string `json:"update_baseline"` // 更新基线, 即首页第一条动态的 id, 仅全部动态列表
+		UpdateNum      int    `json:"update_num"`      // 自 update_baseline 以来的新动态数, 仅全部动态列表
+	} `json:"data"` // 数据本体
+}
+
+// UpdateNumResponse 新动态数
+type UpdateNumResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		UpdateNum int `json:"update_num"` // 自 update_baseline 以来的新动态数
+	} `json:"data"` // 数据本体
+}
+
+// DetailResponse 动态详情
+type DetailResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -352表示风控校验失败, 4101131表示动态已删除, 500表示动态不存在
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Item Item `json:"item"` // 动态
+	} `json:"data"` // 数据本体
+}
@@ -0,0 +1,62 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedFixture(t *testing.T) {
+	b, err := os.ReadFile("testdata/feed_all.json")
+	assert.NoError(t, err)
+
+	var resp FeedResponse
+	assert.NoError(t, json.Unmarshal(b, &resp))
+	assert.Equal(t, "913300000000000003", resp.Data.UpdateBaseline)
+	assert.Equal(t, 2, resp.Data.UpdateNum)
+	items := resp.Data.Items
+	assert.Len(t, items, 4)
+
+	archive, ok := items[0].Modules.Dynamic.Major.Content.(*MajorArchive)
+	assert.True(t, ok)
+	assert.Equal(t, "BV17x411w7KC", archive.Bvid)
+	assert.Equal(t, "10万", archive.Stat.Play)
+	assert.True(t, *items[0].Modules.Author.Following)
+	assert.Equal(t, "https://t.bilibili.com/913300000000000003", items[0].URL())
+
+	opus, ok := items[1].Modules.Dynamic.Major.Content.(*MajorOpus)
+	assert.True(t, ok)
+	assert.Nil(t, opus.Title)
+	assert.Equal(t, "@某人 #话题# [doge]", items[1].Text())
+	nodes := opus.Summary.RichTextNodes
+	assert.Equal(t, NodeAt, nodes[0].Type)
+	assert.Equal(t, "8", nodes[0].Rid)
+	assert.Equal(t, "[doge]", nodes[4].Emoji.Text)
+	assert.Equal(t, 5, items[1].Modules.Dynamic.Additional.Vote.JoinNum)
+
+	forward := items[2]
+	assert.Equal(t, TypeForward, forward.Type)
+	assert.Equal(t, "转发动态", forward.Text())
+	assert.Nil(t, forward.Modules.Dynamic.Major)
+	assert.Equal(t, "置顶", forward.Modules.Tag.Text)
+	none, ok := forward.Orig.Modules.Dynamic.Major.Content.(*MajorNone)
+	assert.True(t, ok)
+	assert.Equal(t, "源动态已被作者删除", none.Tips)
+
+	unknown := items[3].Modules.Dynamic.Major
+	assert.Equal(t, "MAJOR_TYPE_FUTURE", unknown.Type)
+	assert.Nil(t, unknown.Content)
+}
+
+func TestMajorRoundTrip(t *testing.T) {
+	in := Major{Type: MajorTypeArticle, Content: &MajorArticle{ID: 1, Title: "专栏"}}
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+
+	var out Major
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, in, out)
+	assert.Equal(t, MajorTypeArticle, out.Content.MajorType())
+}
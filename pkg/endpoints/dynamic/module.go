@@ -0,0 +1,412 @@
+package dynamic
+
+import "encoding/json"
+
+// 动态类型
+const (
+	TypeNone      = "DYNAMIC_TYPE_NONE"          // 无效动态 (如源动态已删除)
+	TypeForward   = "DYNAMIC_TYPE_FORWARD"       // 转发动态
+	TypeAV        = "DYNAMIC_TYPE_AV"            // 投稿视频
+	TypePGC       = "DYNAMIC_TYPE_PGC"           // 剧集
+	TypeWord      = "DYNAMIC_TYPE_WORD"          // 纯文字动态
+	TypeDraw      = "DYNAMIC_TYPE_DRAW"          // 带图动态
+	TypeArticle   = "DYNAMIC_TYPE_ARTICLE"       // 投稿专栏
+	TypeMusic     = "DYNAMIC_TYPE_MUSIC"         // 音乐
+	TypeCommon    = "DYNAMIC_TYPE_COMMON_SQUARE" // 装扮/剧集点评/普通分享
+	TypeLive      = "DYNAMIC_TYPE_LIVE"          // 直播间分享
+	TypeLiveRcmd  = "DYNAMIC_TYPE_LIVE_RCMD"     // 直播开播
+	TypeUGCSeason = "DYNAMIC_TYPE_UGC_SEASON"    // 合集更新
+)
+
+// 富文本节点类型
+const (
+	NodeText    = "RICH_TEXT_NODE_TYPE_TEXT"    // 文字
+	NodeAt      = "RICH_TEXT_NODE_TYPE_AT"      // @用户
+	NodeTopic   = "RICH_TEXT_NODE_TYPE_TOPIC"   // 话题
+	NodeEmoji   = "RICH_TEXT_NODE_TYPE_EMOJI"   // 表情
+	NodeWeb     = "RICH_TEXT_NODE_TYPE_WEB"     // 网页链接
+	NodeBV      = "RICH_TEXT_NODE_TYPE_BV"      // 视频链接
+	NodeLottery = "RICH_TEXT_NODE_TYPE_LOTTERY" // 互动抽奖
+	NodeVote    = "RICH_TEXT_NODE_TYPE_VOTE"    // 投票
+	NodeGoods   = "RICH_TEXT_NODE_TYPE_GOODS"   // 商品
+)
+
+// Item 动态
+type Item struct {
+	IDStr   string  `json:"id_str"`  // 动态 id
+	Type    string  `json:"type"`    // 动态类型, 见 TypeAV 等
+	Visible bool    `json:"visible"` // 是否显示
+	Basic   Basic   `json:"basic"`   // 评论区等基础信息
+	Modules Modules `json:"modules"` // 动态内容
+	Orig    *Item   `json:"orig"`    // 被转发的源动态, 仅转发动态
+}
+
+// URL 动态页面地址
+func (i *Item) URL() string {
+	return "https://t.bilibili.com/" + i.IDStr
+}
+
+// Text 动态正文, 图文动态 (opus) 的正文位于 Major 中
+func (i *Item) Text() string {
+	d := i.Modules.Dynamic
+	if d == nil {
+		return ""
+	}
+	if d.Desc != nil && d.Desc.Text != "" {
+		return d.Desc.Text
+	}
+	if d.Major != nil {
+		if opus, ok := d.Major.Content.(*MajorOpus); ok {
+			return opus.Summary.Text
+		}
+	}
+	return ""
+}
+
+// Basic 动态基础信息
+type Basic struct {
+	CommentIDStr string `json:"comment_id_str"` // 评论区 oid
+	CommentType  int    `json:"comment_type"`   // 评论区类型, 见 comment.TypeVideo 等
+	RidStr       string `json:"rid_str"`        // 关联内容 id, 如视频 avid
+	JumpURL      string `json:"jump_url"`       // 跳转 url
+	LikeIcon     struct {
+		ActionURL string `json:"action_url"` // 点赞动画
+		EndURL    string `json:"end_url"`    // 作用尚不明确
+		ID        int    `json:"id"`         // 作用尚不明确
+		StartURL  string `json:"start_url"`  // 作用尚不明确
+	} `json:"like_icon"` // 点赞图标
+}
+
+// Modules 动态的各个模块
+type Modules struct {
+	Author  ModuleAuthor   `json:"module_author"`  // 作者信息
+	Dynamic *ModuleDynamic `json:"module_dynamic"` // 动态内容
+	Stat    *ModuleStat    `json:"module_stat"`    // 统计数据, 源动态中不存在
+	Tag     *struct {
+		Text string `json:"text"` // 标签文字, 如 "置顶"
+	} `json:"module_tag"` // 标签
+}
+
+// ModuleAuthor 作者信息
+type ModuleAuthor struct {
+	Mid       int    `json:"mid"`        // 用户 mid, 剧集等为0
+	Name      string `json:"name"`       // 昵称
+	Face      string `json:"face"`       // 头像
+	Type      string `json:"type"`       // 作者类型, 如 AUTHOR_TYPE_NORMAL, AUTHOR_TYPE_PGC
+	PubTime   string `json:"pub_time"`   // 发布时间文本, 如 "2小时前"
+	PubTs     int64  `json:"pub_ts"`     // 发布时间, 秒级时间戳
+	PubAction string `json:"pub_action"` // 发布动作, 如 "投稿了视频"
+	JumpURL   string `json:"jump_url"`   // 空间跳转 url
+	Following *bool  `json:"following"`  // 当前用户是否已关注, 未登录时为 null
+	Label     string `json:"label"`      // 名称前标签, 如 "合集"
+}
+
+// ModuleDynamic 动态内容
+type ModuleDynamic struct {
+	Desc       *Desc       `json:"desc"`       // 动态正文, 无正文时为 null
+	Major      *Major      `json:"major"`      // 动态主体, 纯文字动态时为 null
+	Additional *Additional `json:"additional"` // 附加卡片, 无时为 null
+	Topic      *struct {
+		ID      int    `json:"id"`       // 话题 id
+		Name    string `json:"name"`     // 话题名
+		JumpURL string `json:"jump_url"` // 跳转 url
+	} `json:"topic"` // 话题, 无时为 null
+}
+
+// Desc 带富文本的正文
+type Desc struct {
+	Text          string         `json:"text"`            // 纯文本
+	RichTextNodes []RichTextNode `json:"rich_text_nodes"` // 富文本节点
+}
+
+// RichTextNode 富文本节点
+type RichTextNode struct {
+	Type     string `json:"type"`      // 节点类型, 见 NodeText 等
+	Text     string `json:"text"`      // 显示文字
+	OrigText string `json:"orig_text"` // 原始文字
+	Rid      string `json:"rid"`       // 关联 id, 如 @ 的用户 mid, 视频 bvid
+	JumpURL  string `json:"jump_url"`  // 跳转 url
+	Emoji    *struct {
+		IconURL string `json:"icon_url"` // 表情图片 url
+		Size    int    `json:"size"`     // 尺寸, 1: 小, 2: 大
+		Text    string `json:"text"`     // 表情文本, 如 "[doge]"
+		Type    int    `json:"type"`     // 表情类型
+	} `json:"emoji"` // 表情, 仅表情节点
+}
+
+// ModuleStat 统计数据
+type ModuleStat struct {
+	Comment struct {
+		Count     int  `json:"count"`     // 评论数
+		Forbidden bool `json:"forbidden"` // 是否禁止评论
+	} `json:"comment"` // 评论
+	Forward struct {
+		Count     int  `json:"count"`     // 转发数
+		Forbidden bool `json:"forbidden"` // 是否禁止转发
+	} `json:"forward"` // 转发
+	Like struct {
+		Count     int  `json:"count"`     // 点赞数
+		Forbidden bool `json:"forbidden"` // 是否禁止点赞
+		Status    bool `json:"status"`    // 当前用户是否已点赞
+	} `json:"like"` // 点赞
+}
+
+// Additional 附加卡片, 按 Type 只有对应字段非空
+type Additional struct {
+	Type    string `json:"type"` // 卡片类型, 如 ADDITIONAL_TYPE_RESERVE, ADDITIONAL_TYPE_VOTE, ADDITIONAL_TYPE_UGC, ADDITIONAL_TYPE_COMMON
+	Reserve *struct {
+		Rid          int    `json:"rid"`           // 预约 id
+		Title        string `json:"title"`         // 预约标题
+		UpMid        int    `json:"up_mid"`        // 预约发起人 mid
+		ReserveTotal int    `json:"reserve_total"` // 预约人数
+		State        int    `json:"state"`         // 作用尚不明确
+		Stype        int    `json:"stype"`         // 预约类型
+		JumpURL      string `json:"jump_url"`      // 跳转 url
+	} `json:"reserve"` // 预约
+	Vote *struct {
+		VoteID    int    `json:"vote_id"`    // 投票 id
+		Desc      string `json:"desc"`       // 投票标题
+		JoinNum   int    `json:"join_num"`   // 参与人数
+		EndTime   int64  `json:"end_time"`   // 结束时间
+		ChoiceCnt int    `json:"choice_cnt"` // 可选数量
+		Status    int    `json:"status"`     // 作用尚不明确
+	} `json:"vote"` // 投票
+	Ugc *struct {
+		IDStr      string `json:"id_str"`      // 视频 avid
+		Title      string `json:"title"`       // 视频标题
+		Cover      string `json:"cover"`       // 视频封面
+		DescSecond string `json:"desc_second"` // 播放量与弹幕数
+		Duration   string `json:"duration"`    // 时长文本
+		JumpURL    string `json:"jump_url"`    // 跳转 url
+	} `json:"ugc"` // 视频
+	Common *struct {
+		IDStr    string `json:"id_str"`    // 卡片 id
+		Title    string `json:"title"`     // 标题
+		SubType  string `json:"sub_type"`  // 子类型, 如 game, decoration, ogv
+		Cover    string `json:"cover"`     // 封面
+		Desc1    string `json:"desc1"`     // 描述1
+		Desc2    string `json:"desc2"`     // 描述2
+		HeadText string `json:"head_text"` // 头部文字
+		JumpURL  string `json:"jump_url"`  // 跳转 url
+		Style    int    `json:"style"`     // 作用尚不明确
+	} `json:"common"` // 普通卡片
+}
+
+// 动态主体类型
+const (
+	MajorTypeNone     = "MAJOR_TYPE_NONE"      // 动态失效
+	MajorTypeArchive  = "MAJOR_TYPE_ARCHIVE"   // 视频
+	MajorTypeDraw     = "MAJOR_TYPE_DRAW"      // 带图动态
+	MajorTypeArticle  = "MAJOR_TYPE_ARTICLE"   // 专栏
+	MajorTypeOpus     = "MAJOR_TYPE_OPUS"      // 图文动态
+	MajorTypeLive     = "MAJOR_TYPE_LIVE"      // 直播间分享
+	MajorTypeLiveRcmd = "MAJOR_TYPE_LIVE_RCMD" // 直播开播
+	MajorTypePGC      = "MAJOR_TYPE_PGC"       // 剧集
+	MajorTypeMusic    = "MAJOR_TYPE_MUSIC"     // 音乐
+	MajorTypeCommon   = "MAJOR_TYPE_COMMON"    // 普通卡片
+)
+
+// Major 动态主体, 按 Type 解析为对应的 MajorContent
+//
+// 用法:
+//
+//	switch c := item.Modules.Dynamic.Major.Content.(type) {
+//	case *dynamic.MajorArchive:
+//		fmt.Println(c.Bvid)
+//	case *dynamic.MajorDraw:
+//		fmt.Println(len(c.Items))
+//	}
+type Major struct {
+	Type    string       // 主体类型, 见 MajorTypeArchive 等
+	Content MajorContent // 主体内容, 未知类型时为 nil
+}
+
+// MajorContent 动态主体内容, 实现为 *MajorArchive 等
+type MajorContent interface {
+	MajorType() string
+}
+
+// 主体类型对应的 json 字段名及构造函数
+var majorKinds = map[string]struct {
+	key string
+	new func() MajorContent
+}{
+	MajorTypeNone:     {"none", func() MajorContent { return &MajorNone{} }},
+	MajorTypeArchive:  {"archive", func() MajorContent { return &MajorArchive{} }},
+	MajorTypeDraw:     {"draw", func() MajorContent { return &MajorDraw{} }},
+	MajorTypeArticle:  {"article", func() MajorContent { return &MajorArticle{} }},
+	MajorTypeOpus:     {"opus", func() MajorContent { return &MajorOpus{} }},
+	MajorTypeLive:     {"live", func() MajorContent { return &MajorLive{} }},
+	MajorTypeLiveRcmd: {"live_rcmd", func() MajorContent { return &MajorLiveRcmd{} }},
+	MajorTypePGC:      {"pgc", func() MajorContent { return &MajorPGC{} }},
+	MajorTypeMusic:    {"music", func() MajorContent { return &MajorMusic{} }},
+	MajorTypeCommon:   {"common", func() MajorContent { return &MajorCommon{} }},
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (m *Major) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	m.Type, m.Content = "", nil
+	if t, ok := raw["type"]; ok {
+		if err := json.Unmarshal(t, &m.Type); err != nil {
+			return err
+		}
+	}
+
+	kind, ok := majorKinds[m.Type]
+	if !ok {
+		return nil
+	}
+	content := kind.new()
+	if body, ok := raw[kind.key]; ok && string(body) != "null" {
+		if err := json.Unmarshal(body, content); err != nil {
+			return err
+		}
+	}
+	m.Content = content
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler, 输出与接口相同的结构
+func (m Major) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{"type": m.Type}
+	if kind, ok := majorKinds[m.Type]; ok && m.Content != nil {
+		out[kind.key] = m.Content
+	}
+	return json.Marshal(out)
+}
+
+// Badge 角标
+type Badge struct {
+	Text    string `json:"text"`     // 角标文字, 如 "投稿视频"
+	Color   string `json:"color"`    // 文字颜色
+	BgColor string `json:"bg_color"` // 背景颜色
+}
+
+// MajorNone 失效的动态
+type MajorNone struct {
+	Tips string `json:"tips"` // 提示, 如 "源动态已被作者删除"
+}
+
+// MajorArchive 视频
+type MajorArchive struct {
+	Aid            string `json:"aid"`             // 视频 avid
+	Bvid           string `json:"bvid"`            // 视频 bvid
+	Title          string `json:"title"`           // 视频标题
+	Desc           string `json:"desc"`            // 视频简介
+	Cover          string `json:"cover"`           // 视频封面
+	DurationText   string `json:"duration_text"`   // 时长文本
+	JumpURL        string `json:"jump_url"`        // 跳转 url
+	Type           int    `json:"type"`            // 视频类型, 1: 普通视频, 2: 动态视频
+	DisablePreview int    `json:"disable_preview"` // 是否禁用预览
+	Badge          Badge  `json:"badge"`           // 角标
+	Stat           struct {
+		Danmaku string `json:"danmaku"` // 弹幕数文本
+		Play    string `json:"play"`    // 播放数文本
+	} `json:"stat"` // 统计数据
+}
+
+// MajorDraw 带图动态
+type MajorDraw struct {
+	ID    int `json:"id"` // 相簿 id
+	Items []struct {
+		Src    string  `json:"src"`    // 图片 url
+		Width  int     `json:"width"`  // 宽度
+		Height int     `json:"height"` // 高度
+		Size   float64 `json:"size"`   // 大小, 单位为 KB
+	} `json:"items"` // 图片列表
+}
+
+// MajorArticle 专栏
+type MajorArticle struct {
+	ID      int      `json:"id"`       // 专栏 cvid
+	Title   string   `json:"title"`    // 标题
+	Desc    string   `json:"desc"`     // 摘要
+	Covers  []string `json:"covers"`   // 封面
+	Label   string   `json:"label"`    // 阅读量文本
+	JumpURL string   `json:"jump_url"` // 跳转 url
+}
+
+// MajorOpus 图文动态
+type MajorOpus struct {
+	Title   *string `json:"title"`   // 标题, 无标题时为 null
+	Summary Desc    `json:"summary"` // 正文
+	Pics    []struct {
+		URL    string  `json:"url"`    // 图片 url
+		Width  int     `json:"width"`  // 宽度
+		Height int     `json:"height"` // 高度
+		Size   float64 `json:"size"`   // 大小, 单位为 KB
+	} `json:"pics"` // 图片列表
+	JumpURL    string   `json:"jump_url"`    // 跳转 url
+	FoldAction []string `json:"fold_action"` // 展开收起文字
+}
+
+// MajorLive 直播间分享
+type MajorLive struct {
+	ID         int    `json:"id"`          // 直播间 id
+	Title      string `json:"title"`       // 直播间标题
+	Cover      string `json:"cover"`       // 直播间封面
+	DescFirst  string `json:"desc_first"`  // 分区名
+	DescSecond string `json:"desc_second"` // 观看人数文本
+	LiveState  int    `json:"live_state"`  // 直播状态, 0: 未开播, 1: 直播中
+	JumpURL    string `json:"jump_url"`    // 跳转 url
+	Badge      Badge  `json:"badge"`       // 角标
+}
+
+// MajorLiveRcmd 直播开播
+type MajorLiveRcmd struct {
+	Content     string `json:"content"`      // 直播间信息, json 字符串
+	ReserveType int    `json:"reserve_type"` // 作用尚不明确
+}
+
+// MajorPGC 剧集
+type MajorPGC struct {
+	Epid     int    `json:"epid"`      // 分集 epid
+	SeasonID int    `json:"season_id"` // 剧集 ssid
+	SubType  int    `json:"sub_type"`  // 剧集类型, 1: 番剧, 2: 电影, 3: 纪录片, 4: 国创, 5: 电视剧
+	Type     int    `json:"type"`      // 作用尚不明确
+	Title    string `json:"title"`     // 标题
+	Cover    string `json:"cover"`     // 封面
+	JumpURL  string `json:"jump_url"`  // 跳转 url
+	Badge    Badge  `json:"badge"`     // 角标
+	Stat     struct {
+		Danmaku string `json:"danmaku"` // 弹幕数文本
+		Play    string `json:"play"`    // 播放数文本
+	} `json:"stat"` // 统计数据
+}
+
+// MajorMusic 音乐
+type MajorMusic struct {
+	ID      int    `json:"id"`       // 音频 auid
+	Title   string `json:"title"`    // 标题
+	Cover   string `json:"cover"`    // 封面
+	Label   string `json:"label"`    // 分类
+	JumpURL string `json:"jump_url"` // 跳转 url
+}
+
+// MajorCommon 普通卡片, 如装扮、剧集点评
+type MajorCommon struct {
+	ID       string `json:"id"`        // 卡片 id
+	Title    string `json:"title"`     // 标题
+	Desc     string `json:"desc"`      // 描述
+	Cover    string `json:"cover"`     // 封面
+	Label    string `json:"label"`     // 标签
+	JumpURL  string `json:"jump_url"`  // 跳转 url
+	SketchID string `json:"sketch_id"` // 作用尚不明确
+	BizType  int    `json:"biz_type"`  // 业务类型
+	Badge    Badge  `json:"badge"`     // 角标
+}
+
+func (*MajorNone) MajorType() string     { return MajorTypeNone }
+func (*MajorArchive) MajorType() string  { return MajorTypeArchive }
+func (*MajorDraw) MajorType() string     { return MajorTypeDraw }
+func (*MajorArticle) MajorType() string  { return MajorTypeArticle }
+func (*MajorOpus) MajorType() string     { return MajorTypeOpus }
+func (*MajorLive) MajorType() string     { return MajorTypeLive }
+func (*MajorLiveRcmd) MajorType() string { return MajorTypeLiveRcmd }
+func (*MajorPGC) MajorType() string      { return MajorTypePGC }
+func (*MajorMusic) MajorType() string    { return MajorTypeMusic }
+func (*MajorCommon) MajorType() string   { return MajorTypeCommon }
@@ -0,0 +1,554 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 关注动态事件类型
+const (
+	EventVideo   = "video"   // 投稿视频
+	EventArticle = "article" // 投稿专栏
+	EventDynamic = "dynamic" // 其他动态, 如图文、转发、直播
+)
+
+// Event 关注的 UP 主发布了新内容
+type Event struct {
+	Kind    string    `json:"kind"`     // 事件类型, 见 EventVideo 等
+	ID      string    `json:"id"`       // 动态 id
+	Mid     int       `json:"mid"`      // 作者 mid
+	Author  string    `json:"author"`   // 作者昵称
+	Title   string    `json:"title"`    // 标题, 无标题的动态取正文开头
+	Text    string    `json:"text"`     // 正文或简介
+	URL     string    `json:"url"`      // 内容页面地址
+	PubTime time.Time `json:"pub_time"` // 发布时间
+	Item    Item      `json:"item"`     // 原始动态
+}
+
+// 将动态转换为事件
+func NewEvent(item Item) Event {
+	author := item.Modules.Author
+	e := Event{
+		Kind:    EventDynamic,
+		ID:      item.IDStr,
+		Mid:     author.Mid,
+		Author:  author.Name,
+		Text:    item.Text(),
+		URL:     item.URL(),
+		PubTime: time.Unix(author.PubTs, 0),
+		Item:    item,
+	}
+
+	var major MajorContent
+	if d := item.Modules.Dynamic; d != nil && d.Major != nil {
+		major = d.Major.Content
+	}
+	switch c := major.(type) {
+	case *MajorArchive:
+		if item.Type == TypeAV {
+			e.Kind = EventVideo
+		}
+		e.Title, e.URL = c.Title, "https://www.bilibili.com/video/"+c.Bvid
+		if e.Text == "" {
+			e.Text = c.Desc
+		}
+	case *MajorArticle:
+		e.Kind = EventArticle
+		e.Title, e.URL = c.Title, fmt.Sprintf("https://www.bilibili.com/read/cv%d", c.ID)
+		if e.Text == "" {
+			e.Text = c.Desc
+		}
+	case *MajorOpus:
+		if item.Type == TypeArticle {
+			e.Kind = EventArticle
+		}
+		if c.Title != nil {
+			e.Title = *c.Title
+		}
+	}
+	if e.Title == "" {
+		e.Title = truncate(e.Text, 40)
+	}
+	return e
+}
+
+// Handler 事件处理函数
+type Handler func(e Event)
+
+// WatcherState 关注动态监控的持久化状态
+type WatcherState struct {
+	UpdateBaseline string   `json:"update_baseline"`   // 更新基线
+	Offset         string   `json:"offset"`            // 最近一次首页的翻页游标
+	Seen           []string `json:"seen"`              // 最近处理过的动态 id, 从旧到新
+	Recent         []Event  `json:"recent,omitempty"`  // 最近的事件, 用于生成 RSS, 从新到旧
+	Pending        []Event  `json:"pending,omitempty"` // 尚未送达的事件, 从旧到新
+}
+
+// Watcher 轮询关注动态, UP 主发布新视频、专栏或动态时触发事件
+//
+// 备注：
+//   - 每轮先请求 UpdateNum, 有新动态时才翻页拉取, 直到遇到已处理的动态
+//   - 首次运行仅记录当前动态, 不触发事件
+//   - 动态按 id_str 去重, 状态保存在 StatePath, 重启后从上次位置继续
+//   - 事件按发布先后顺序分发给处理函数, 设置 Webhook 后以 JSON POST 到该地址
+//   - 事件先保存到状态再分发, 推送失败的事件在下一轮按顺序重试;
+//     重启后尚未送达的事件会重新分发给处理函数
+//   - 设置 RSSPath 后每轮有新事件时重写 RSS 2.0 文件
+type Watcher struct {
+	StatePath string        // 状态文件路径 (可选), 为空时不保存
+	Interval  time.Duration // 轮询周期, 默认为1分钟
+	MaxPages  int           // 每轮最多翻页数, 默认为5
+	SeenLimit int           // 保留的已处理 id 数, 默认为500
+	Webhook   string        // 事件推送地址 (可选)
+	RSSPath   string        // RSS 文件路径 (可选)
+	RSSTitle  string        // RSS 频道标题
+	RSSLimit  int           // RSS 保留的条目数, 默认为50
+
+	OnError func(err error) // 轮询或推送出错回调 (可选)
+
+	feed      func(offset, updateBaseline string, page int) (*FeedResponse, error)
+	updateNum func(updateBaseline string) (*UpdateNumResponse, error)
+	post      func(url string, event Event) error
+
+	mu       sync.Mutex
+	handlers []watchHandler
+	state    WatcherState
+	seen     map[string]bool
+	handled  map[string]bool // 本进程中已分发给处理函数的待送达事件
+	loaded   bool
+}
+
+type watchHandler struct {
+	kinds []string
+	fn    Handler
+}
+
+// 创建关注动态监控
+//
+// Parameters:
+//   - statePath (string): 状态文件路径, 为空时不保存
+func (d *Dynamic) NewWatcher(statePath string) *Watcher {
+	return &Watcher{
+		StatePath: statePath,
+		Interval:  time.Minute,
+		MaxPages:  5,
+		SeenLimit: 500,
+		RSSTitle:  "bilibili 关注动态",
+		RSSLimit:  50,
+		feed: func(offset, updateBaseline string, page int) (*FeedResponse, error) {
+			return d.Feed(FeedAll, offset, updateBaseline, page)
+		},
+		updateNum: func(updateBaseline string) (*UpdateNumResponse, error) {
+			return d.UpdateNum(FeedAll, updateBaseline)
+		},
+		post: func(url string, event Event) error {
+			resp, err := d.client.HTTPClient.R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(event).
+				Post(url)
+			if err != nil {
+				return err
+			}
+			if resp.IsError() {
+				return fmt.Errorf("webhook %s: %s", url, resp.Status())
+			}
+			return nil
+		},
+	}
+}
+
+// Handle 注册事件处理函数
+//
+// Parameters:
+//   - h (Handler): 处理函数
+//   - kinds (...string): 关注的事件类型, 见 EventVideo 等, 为空时接收全部事件
+func (w *Watcher) Handle(h Handler, kinds ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, watchHandler{kinds: kinds, fn: h})
+}
+
+// State 返回当前状态
+func (w *Watcher) State() WatcherState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := w.state
+	s.Seen = append([]string(nil), s.Seen...)
+	s.Recent = append([]Event(nil), s.Recent...)
+	s.Pending = append([]Event(nil), s.Pending...)
+	return s
+}
+
+// Run 按周期轮询, 直到 ctx 结束
+//
+// 备注：
+//   - 单轮出错时调用 OnError 并在下一周期重试
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.PollOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollOnce 轮询一次, 返回本轮的新事件, 从旧到新
+func (w *Watcher) PollOnce(ctx context.Context) ([]Event, error) {
+	if !w.loaded {
+		if err := w.load(); err != nil {
+			return nil, err
+		}
+		w.loaded = true
+	}
+
+	if w.state.UpdateBaseline != "" {
+		resp, err := w.updateNum(w.state.UpdateBaseline)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		if resp.Data.UpdateNum == 0 {
+			return nil, w.deliver()
+		}
+	}
+
+	items, first, err := w.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 首次运行只建立基线
+	initial := w.state.UpdateBaseline == ""
+	var events []Event
+	w.mu.Lock()
+	for i := len(items) - 1; i >= 0; i-- {
+		id := items[i].IDStr
+		if id == "" || w.seen[id] {
+			continue
+		}
+		w.markSeen(id)
+		if !initial {
+			events = append(events, NewEvent(items[i]))
+		}
+	}
+
+	w.state.UpdateBaseline = first.UpdateBaseline
+	if w.state.UpdateBaseline == "" && len(items) > 0 {
+		w.state.UpdateBaseline = items[0].IDStr
+	}
+	w.state.Offset = first.Offset
+	if w.RSSPath != "" {
+		w.addRecent(events)
+	}
+	w.state.Pending = append(w.state.Pending, events...)
+	err = w.save()
+	w.mu.Unlock()
+	if err != nil {
+		return events, err
+	}
+
+	err = w.deliver()
+	if w.RSSPath != "" && len(events) > 0 {
+		if rssErr := w.writeRSS(); rssErr != nil {
+			return events, errors.Join(err, rssErr)
+		}
+	}
+	return events, err
+}
+
+// 从首页开始翻页, 直到遇到已处理的动态, 返回拉取到的动态 (从新到旧) 及首页数据
+func (w *Watcher) fetch(ctx context.Context) ([]Item, feedPage, error) {
+	maxPages := w.MaxPages
+	if maxPages <= 0 {
+		maxPages = 5
+	}
+
+	var (
+		items  []Item
+		first  feedPage
+		offset string
+	)
+	for page := 1; page <= maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, first, err
+		}
+		resp, err := w.feed(offset, w.state.UpdateBaseline, page)
+		if err != nil {
+			return nil, first, err
+		}
+		if resp.Code != 0 {
+			return nil, first, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		if page == 1 {
+			first = feedPage{UpdateBaseline: resp.Data.UpdateBaseline, Offset: resp.Data.Offset}
+		}
+
+		reached := false
+		for _, item := range resp.Data.Items {
+			if w.seen[item.IDStr] {
+				reached = true
+				continue
+			}
+			items = append(items, item)
+		}
+		// 首次运行只需要首页
+		if reached || w.state.UpdateBaseline == "" || !resp.Data.HasMore || resp.Data.Offset == "" {
+			break
+		}
+		offset = resp.Data.Offset
+	}
+	return items, first, nil
+}
+
+type feedPage struct {
+	UpdateBaseline string
+	Offset         string
+}
+
+func (w *Watcher) markSeen(id string) {
+	limit := w.SeenLimit
+	if limit <= 0 {
+		limit = 500
+	}
+	w.seen[id] = true
+	w.state.Seen = append(w.state.Seen, id)
+	if n := len(w.state.Seen) - limit; n > 0 {
+		for _, old := range w.state.Seen[:n] {
+			delete(w.seen, old)
+		}
+		w.state.Seen = append([]string(nil), w.state.Seen[n:]...)
+	}
+}
+
+// 将新事件按从新到旧加入 Recent
+func (w *Watcher) addRecent(events []Event) {
+	limit := w.RSSLimit
+	if limit <= 0 {
+		limit = 50
+	}
+	recent := make([]Event, 0, len(events)+len(w.state.Recent))
+	for i := len(events) - 1; i >= 0; i-- {
+		recent = append(recent, events[i])
+	}
+	recent = append(recent, w.state.Recent...)
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+	w.state.Recent = recent
+}
+
+// 分发待送达的事件: 未分发过的先交给处理函数, 再按顺序推送到 Webhook,
+// 推送失败时保留该事件及之后的事件, 下一轮重试
+func (w *Watcher) deliver() error {
+	w.mu.Lock()
+	pending := append([]Event(nil), w.state.Pending...)
+	handlers := append([]watchHandler(nil), w.handlers...)
+	w.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	for _, e := range pending {
+		if w.handled[e.ID] {
+			continue
+		}
+		for _, h := range handlers {
+			if len(h.kinds) == 0 || containsString(h.kinds, e.Kind) {
+				h.fn(e)
+			}
+		}
+		w.handled[e.ID] = true
+	}
+
+	delivered := len(pending)
+	var err error
+	if w.Webhook != "" {
+		for i, e := range pending {
+			if err = w.post(w.Webhook, e); err != nil {
+				delivered = i
+				break
+			}
+		}
+	}
+	if delivered == 0 {
+		return err
+	}
+
+	w.mu.Lock()
+	for _, e := range pending[:delivered] {
+		delete(w.handled, e.ID)
+	}
+	w.state.Pending = append([]Event(nil), w.state.Pending[delivered:]...)
+	saveErr := w.save()
+	w.mu.Unlock()
+	return errors.Join(err, saveErr)
+}
+
+func (w *Watcher) load() error {
+	w.state = WatcherState{}
+	w.seen = map[string]bool{}
+	w.handled = map[string]bool{}
+	if w.StatePath == "" {
+		return nil
+	}
+	b, err := os.ReadFile(w.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &w.state); err != nil {
+		return fmt.Errorf("invalid watcher state %s: %w", w.StatePath, err)
+	}
+	for _, id := range w.state.Seen {
+		w.seen[id] = true
+	}
+	return nil
+}
+
+// 写入状态, 先写临时文件再替换, 避免中断时损坏, 调用时需持有 w.mu
+func (w *Watcher) save() error {
+	if w.StatePath == "" {
+		return nil
+	}
+	b, err := json.Marshal(&w.state)
+	if err != nil {
+		return err
+	}
+	tmp := w.StatePath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.StatePath)
+}
+
+func (w *Watcher) writeRSS() error {
+	tmp := w.RSSPath + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := WriteRSS(f, w.RSSTitle, "https://t.bilibili.com", w.State().Recent); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.RSSPath)
+}
+
+// 将事件写为 RSS 2.0
+//
+// Parameters:
+//   - w (io.Writer): 输出
+//   - title (string): 频道标题
+//   - link (string): 频道地址
+//   - events ([]Event): 事件, 按给定顺序输出
+func WriteRSS(w io.Writer, title, link string, events []Event) error {
+	doc := rssDoc{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       title,
+			Link:        link,
+			Description: title,
+		},
+	}
+	for _, e := range events {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       fmt.Sprintf("[%s] %s", e.Author, e.Title),
+			Link:        e.URL,
+			GUID:        rssGUID{IsPermaLink: false, Value: e.ID},
+			PubDate:     e.PubTime.Format(time.RFC1123Z),
+			Category:    e.Kind,
+			Description: e.Text,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type rssDoc struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// 截取前 n 个字符, 过长时以 "…" 结尾
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\n", " "))
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
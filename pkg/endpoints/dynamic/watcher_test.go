@@ -0,0 +1,224 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func watchItem(id, typ string, major *Major, text string) Item {
+	return Item{
+		IDStr: id,
+		Type:  typ,
+		Modules: Modules{
+			Author:  ModuleAuthor{Mid: 2, Name: "碧诗", PubTs: 1700000000},
+			Dynamic: &ModuleDynamic{Desc: &Desc{Text: text}, Major: major},
+		},
+	}
+}
+
+// 模拟关注动态: 从新到旧, 每页2条, 游标为下一页起始下标
+type fakeFeed struct {
+	items []Item
+	calls int
+}
+
+func (f *fakeFeed) feed(offset, updateBaseline string, page int) (*FeedResponse, error) {
+	f.calls++
+	start, _ := strconv.Atoi(offset)
+	end := start + 2
+	if end > len(f.items) {
+		end = len(f.items)
+	}
+	resp := &FeedResponse{}
+	resp.Data.Items = f.items[start:end]
+	resp.Data.HasMore = end < len(f.items)
+	resp.Data.Offset = strconv.Itoa(end)
+	resp.Data.UpdateBaseline = f.items[0].IDStr
+	return resp, nil
+}
+
+func (f *fakeFeed) updateNum(updateBaseline string) (*UpdateNumResponse, error) {
+	resp := &UpdateNumResponse{}
+	for _, item := range f.items {
+		if item.IDStr == updateBaseline {
+			break
+		}
+		resp.Data.UpdateNum++
+	}
+	return resp, nil
+}
+
+func newTestWatcher(f *fakeFeed, dir string) *Watcher {
+	w := New(nil).NewWatcher(filepath.Join(dir, "state.json"))
+	w.feed = f.feed
+	w.updateNum = f.updateNum
+	return w
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	f := &fakeFeed{items: []Item{
+		watchItem("102", TypeWord, nil, "旧动态2"),
+		watchItem("101", TypeWord, nil, "旧动态1"),
+		watchItem("100", TypeWord, nil, "旧动态0"),
+	}}
+
+	w := newTestWatcher(f, dir)
+	w.RSSPath = filepath.Join(dir, "feed.xml")
+	var posted []Event
+	w.Webhook = "http://hook"
+	w.post = func(url string, e Event) error {
+		posted = append(posted, e)
+		return nil
+	}
+	var all, videos []Event
+	w.Handle(func(e Event) { all = append(all, e) })
+	w.Handle(func(e Event) { videos = append(videos, e) }, EventVideo)
+
+	// 首次运行只建立基线
+	events, err := w.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, "102", w.State().UpdateBaseline)
+	assert.Equal(t, "2", w.State().Offset)
+
+	// 无新动态时不请求列表
+	calls := f.calls
+	events, err = w.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, calls, f.calls)
+
+	title := "新视频"
+	f.items = append([]Item{
+		watchItem("105", TypeDraw, &Major{Type: MajorTypeOpus, Content: &MajorOpus{Title: &title}}, "图文"),
+		watchItem("104", TypeArticle, &Major{Type: MajorTypeArticle, Content: &MajorArticle{ID: 7, Title: "专栏"}}, ""),
+		watchItem("103", TypeAV, &Major{Type: MajorTypeArchive, Content: &MajorArchive{Bvid: "BV1xx", Title: "视频", Desc: "简介"}}, ""),
+	}, f.items...)
+
+	events, err = w.PollOnce(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, EventVideo, events[0].Kind)
+		assert.Equal(t, "视频", events[0].Title)
+		assert.Equal(t, "简介", events[0].Text)
+		assert.Equal(t, "https://www.bilibili.com/video/BV1xx", events[0].URL)
+		assert.Equal(t, EventArticle, events[1].Kind)
+		assert.Equal(t, "https://www.bilibili.com/read/cv7", events[1].URL)
+		assert.Equal(t, EventDynamic, events[2].Kind)
+		assert.Equal(t, "新视频", events[2].Title)
+		assert.Equal(t, "https://t.bilibili.com/105", events[2].URL)
+	}
+	assert.Equal(t, events, all)
+	assert.Equal(t, events, posted)
+	assert.Len(t, videos, 1)
+	assert.Equal(t, "105", w.State().UpdateBaseline)
+
+	b, err := os.ReadFile(w.RSSPath)
+	assert.NoError(t, err)
+	var doc rssDoc
+	assert.NoError(t, xml.Unmarshal(b, &doc))
+	if assert.Len(t, doc.Channel.Items, 3) {
+		assert.Equal(t, "[碧诗] 新视频", doc.Channel.Items[0].Title)
+		assert.Equal(t, "105", doc.Channel.Items[0].GUID.Value)
+		assert.Equal(t, "[碧诗] 视频", doc.Channel.Items[2].Title)
+	}
+
+	// 重启后从保存的状态继续, 已处理的动态不会重复触发
+	f.items = append([]Item{watchItem("106", TypeWord, nil, "第一行\n第二行")}, f.items...)
+	w2 := newTestWatcher(f, dir)
+	events, err = w2.PollOnce(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "106", events[0].ID)
+		assert.Equal(t, "第一行 第二行", events[0].Title)
+	}
+	assert.Equal(t, []string{"101", "102", "103", "104", "105", "106"}, w2.State().Seen)
+	assert.Len(t, w2.State().Recent, 3)
+}
+
+func TestWatcherSeenLimit(t *testing.T) {
+	f := &fakeFeed{items: []Item{watchItem("2", TypeWord, nil, ""), watchItem("1", TypeWord, nil, "")}}
+	w := newTestWatcher(f, t.TempDir())
+	w.SeenLimit = 1
+
+	_, err := w.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, w.State().Seen)
+}
+
+func TestWatcherWebhookRetry(t *testing.T) {
+	dir := t.TempDir()
+	f := &fakeFeed{items: []Item{watchItem("1", TypeWord, nil, "")}}
+	w := newTestWatcher(f, dir)
+	w.Webhook = "http://hook"
+	var posted []string
+	down := true
+	w.post = func(url string, e Event) error {
+		if down {
+			return errors.New("webhook down")
+		}
+		posted = append(posted, e.ID)
+		return nil
+	}
+	var handled []string
+	w.Handle(func(e Event) { handled = append(handled, e.ID) })
+
+	_, err := w.PollOnce(context.Background())
+	assert.NoError(t, err)
+
+	// 推送失败的事件保留在状态中, 处理函数只调用一次
+	f.items = append([]Item{watchItem("3", TypeWord, nil, ""), watchItem("2", TypeWord, nil, "")}, f.items...)
+	events, err := w.PollOnce(context.Background())
+	assert.Error(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, []string{"2", "3"}, handled)
+	if assert.Len(t, w.State().Pending, 2) {
+		assert.Equal(t, "2", w.State().Pending[0].ID)
+	}
+
+	// 无新动态时也会重试推送
+	down = false
+	events, err = w.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, []string{"2", "3"}, posted)
+	assert.Equal(t, []string{"2", "3"}, handled)
+	assert.Empty(t, w.State().Pending)
+}
+
+func TestWatcherPendingAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	f := &fakeFeed{items: []Item{watchItem("1", TypeWord, nil, "")}}
+	w := newTestWatcher(f, dir)
+	w.Webhook = "http://hook"
+	w.post = func(url string, e Event) error { return errors.New("webhook down") }
+	_, err := w.PollOnce(context.Background())
+	assert.NoError(t, err)
+	f.items = append([]Item{watchItem("2", TypeWord, nil, "")}, f.items...)
+	_, err = w.PollOnce(context.Background())
+	assert.Error(t, err)
+
+	// 重启后未送达的事件重新分发
+	w2 := newTestWatcher(f, dir)
+	w2.Webhook = "http://hook"
+	var posted, handled []string
+	w2.post = func(url string, e Event) error {
+		posted = append(posted, e.ID)
+		return nil
+	}
+	w2.Handle(func(e Event) { handled = append(handled, e.ID) })
+	events, err := w2.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, []string{"2"}, posted)
+	assert.Equal(t, []string{"2"}, handled)
+	assert.Empty(t, w2.State().Pending)
+}
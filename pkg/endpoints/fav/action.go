@@ -1 +1,239 @@
 package fav
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 收藏内容类型
+const (
+	ResourceVideo      = 2  // 视频稿件
+	ResourceAudio      = 12 // 音频
+	ResourceCollection = 21 // 视频合集
+)
+
+// Resource 批量操作中的内容标识
+type Resource struct {
+	ID   int // 内容 id, 视频稿件为 avid
+	Type int // 内容类型, 见 ResourceVideo 等
+}
+
+// String 返回接口所需的 "id:type" 格式
+func (r Resource) String() string {
+	return fmt.Sprintf("%d:%d", r.ID, r.Type)
+}
+
+func joinResources(resources []Resource) string {
+	parts := make([]string, len(resources))
+	for i, r := range resources {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("%d", id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// 新建收藏夹
+//
+// Parameters:
+//   - title (string): 收藏夹标题
+//   - intro (string): 收藏夹简介 (可选)
+//   - private (bool): 是否私密
+//   - cover (string): 封面图 url (可选)
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) AddFolder(title, intro string, private bool, cover string) (*FolderInfoResponse, error) {
+	return f.saveFolder("https://api.bilibili.com/x/v3/fav/folder/add", map[string]string{
+		"title":   title,
+		"intro":   intro,
+		"privacy": privacy(private),
+		"cover":   cover,
+	})
+}
+
+// 修改收藏夹
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//   - title (string): 收藏夹标题
+//   - intro (string): 收藏夹简介 (可选)
+//   - private (bool): 是否私密, 默认收藏夹不可修改
+//   - cover (string): 封面图 url (可选)
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) EditFolder(mediaID int, title, intro string, private bool, cover string) (*FolderInfoResponse, error) {
+	return f.saveFolder("https://api.bilibili.com/x/v3/fav/folder/edit", map[string]string{
+		"media_id": fmt.Sprintf("%d", mediaID),
+		"title":    title,
+		"intro":    intro,
+		"privacy":  privacy(private),
+		"cover":    cover,
+	})
+}
+
+func privacy(private bool) string {
+	if private {
+		return "1"
+	}
+	return "0"
+}
+
+func (f *Fav) saveFolder(baseURL string, formData map[string]string) (*FolderInfoResponse, error) {
+	formData["csrf"] = f.client.CSRF
+
+	resp, err := f.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&FolderInfoResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*FolderInfoResponse), nil
+}
+
+// 删除收藏夹
+//
+// Parameters:
+//   - mediaIDs ([]int): 收藏夹 mlid 列表
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+//
+// 备注：
+//   - 默认收藏夹不可删除
+func (f *Fav) DeleteFolders(mediaIDs []int) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/folder/del", map[string]string{
+		"media_ids": joinIDs(mediaIDs),
+	})
+}
+
+// 批量复制内容到另一个收藏夹
+//
+// Parameters:
+//   - srcMediaID (int): 源收藏夹 mlid
+//   - tarMediaID (int): 目标收藏夹 mlid
+//   - mid (int): 当前用户 mid
+//   - resources ([]Resource): 要复制的内容
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) CopyResources(srcMediaID, tarMediaID, mid int, resources []Resource) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/resource/copy", map[string]string{
+		"src_media_id": fmt.Sprintf("%d", srcMediaID),
+		"tar_media_id": fmt.Sprintf("%d", tarMediaID),
+		"mid":          fmt.Sprintf("%d", mid),
+		"resources":    joinResources(resources),
+		"platform":     "web",
+	})
+}
+
+// 批量移动内容到另一个收藏夹
+//
+// Parameters:
+//   - srcMediaID (int): 源收藏夹 mlid
+//   - tarMediaID (int): 目标收藏夹 mlid
+//   - mid (int): 当前用户 mid
+//   - resources ([]Resource): 要移动的内容
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) MoveResources(srcMediaID, tarMediaID, mid int, resources []Resource) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/resource/move", map[string]string{
+		"src_media_id": fmt.Sprintf("%d", srcMediaID),
+		"tar_media_id": fmt.Sprintf("%d", tarMediaID),
+		"mid":          fmt.Sprintf("%d", mid),
+		"resources":    joinResources(resources),
+		"platform":     "web",
+	})
+}
+
+// 批量取消收藏内容
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//   - resources ([]Resource): 要删除的内容
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) DeleteResources(mediaID int, resources []Resource) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/resource/batch-del", map[string]string{
+		"media_id":  fmt.Sprintf("%d", mediaID),
+		"resources": joinResources(resources),
+		"platform":  "web",
+	})
+}
+
+// 清空收藏夹中的失效内容
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) Clean(mediaID int) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/resource/clean", map[string]string{
+		"media_id": fmt.Sprintf("%d", mediaID),
+	})
+}
+
+// 收藏他人的收藏夹
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) FavFolder(mediaID int) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/folder/fav", map[string]string{
+		"media_id": fmt.Sprintf("%d", mediaID),
+	})
+}
+
+// 取消收藏他人的收藏夹
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//
+// Authentication:
+//   - 认证方式：仅可Cookie（SESSDATA）, 需要 csrf
+func (f *Fav) UnfavFolder(mediaID int) (*misc.BaseResponse, error) {
+	return f.post("https://api.bilibili.com/x/v3/fav/folder/unfav", map[string]string{
+		"media_id": fmt.Sprintf("%d", mediaID),
+	})
+}
+
+func (f *Fav) post(baseURL string, formData map[string]string) (*misc.BaseResponse, error) {
+	formData["csrf"] = f.client.CSRF
+
+	resp, err := f.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&misc.BaseResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*misc.BaseResponse), nil
+}
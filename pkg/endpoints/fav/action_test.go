@@ -0,0 +1,32 @@
+package fav
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinResources(t *testing.T) {
+	assert.Equal(t, "170001:2,3:12", joinResources([]Resource{{170001, ResourceVideo}, {3, ResourceAudio}}))
+	assert.Equal(t, "1,2", joinIDs([]int{1, 2}))
+	assert.Equal(t, "", joinResources(nil))
+}
+
+func TestResourcesResponse(t *testing.T) {
+	body := `{"code":0,"message":"0","ttl":1,"data":{"info":{"id":1052622027,"fid":10526220,"mid":7,"attr":22,"title":"默认收藏夹","media_count":2},
+		"medias":[{"id":170001,"type":2,"title":"已失效视频","attr":9,"upper":{"mid":1,"name":"UP"},"bv_id":"BV17x411w7KC","bvid":"BV17x411w7KC","ugc":{"first_cid":279786}}],
+		"has_more":false}}`
+	var resp ResourcesResponse
+	assert.NoError(t, json.Unmarshal([]byte(body), &resp))
+	assert.Equal(t, Resource{170001, ResourceVideo}, resp.Data.Medias[0].Resource())
+	assert.Equal(t, 279786, resp.Data.Medias[0].Ugc.FirstCid)
+
+	brief := FolderBrief{Attr: resp.Data.Info.Attr}
+	assert.False(t, brief.Private())
+	assert.False(t, brief.Default())
+	assert.True(t, FolderBrief{Attr: 1}.Default())
+
+	assert.Equal(t, 0, pageTotal(1, 20, 20, true))
+	assert.Equal(t, 45, pageTotal(3, 20, 5, false))
+}
@@ -0,0 +1,523 @@
+package fav
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/video"
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 失效视频在收藏夹中显示的标题
+const InvalidTitle = "已失效视频"
+
+// BackupItem 备份中的一条收藏内容
+//
+// 备注：
+//   - 内容失效后保留最后一次取得的元数据, 仅更新 Invalid 与 InvalidAt
+//   - 同步失败时同样保留最后一次取得的元数据, 失败原因见 Err
+type BackupItem struct {
+	ID         int      `json:"id"`                    // 内容 id, 视频稿件为 avid
+	Type       int      `json:"type"`                  // 内容类型, 见 ResourceVideo 等
+	Bvid       string   `json:"bvid"`                  // 视频 bvid
+	Title      string   `json:"title"`                 // 标题
+	Intro      string   `json:"intro"`                 // 简介
+	Cover      string   `json:"cover"`                 // 封面 url
+	UpperMid   int      `json:"upper_mid"`             // UP 主 mid
+	UpperName  string   `json:"upper_name"`            // UP 主昵称
+	Duration   int      `json:"duration"`              // 时长, 单位为秒
+	Page       int      `json:"page"`                  // 分P数
+	Cids       []int    `json:"cids,omitempty"`        // 各分P cid, 需开启 Enrich
+	Pubtime    int      `json:"pubtime"`               // 发布时间
+	FavTime    int      `json:"fav_time"`              // 收藏时间
+	Invalid    bool     `json:"invalid"`               // 是否已失效
+	InvalidAt  int64    `json:"invalid_at,omitempty"`  // 首次发现失效的时间
+	RemovedAt  int64    `json:"removed_at,omitempty"`  // 发现已移出收藏夹的时间
+	SyncedAt   int64    `json:"synced_at"`             // 最后一次取得有效元数据的时间
+	CoverFile  string   `json:"cover_file,omitempty"`  // 本地封面路径, 相对于备份目录
+	MediaFiles []string `json:"media_files,omitempty"` // 本地音视频路径, 相对于备份目录
+	Err        string   `json:"err,omitempty"`         // 最近一次同步失败的原因, 成功后清空
+}
+
+// BackupFolder 备份中的一个收藏夹
+type BackupFolder struct {
+	ID       int                    `json:"id"`        // 收藏夹 mlid
+	Title    string                 `json:"title"`     // 收藏夹标题
+	Items    map[string]*BackupItem `json:"items"`     // 内容, 键为 Resource.String()
+	SyncedAt int64                  `json:"synced_at"` // 最后一次同步时间
+}
+
+// BackupStore 备份目录中的索引文件内容
+type BackupStore struct {
+	Mid     int                   `json:"mid"`     // 用户 mid
+	Folders map[int]*BackupFolder `json:"folders"` // 收藏夹, 键为 mlid
+}
+
+// BackupReport 一次备份的统计
+type BackupReport struct {
+	Folders int `json:"folders"` // 同步的收藏夹数
+	Items   int `json:"items"`   // 同步的内容数
+	Added   int `json:"added"`   // 新增的内容数
+	Invalid int `json:"invalid"` // 本次新发现失效的内容数
+	Removed int `json:"removed"` // 本次发现移出收藏夹的内容数
+	Failed  int `json:"failed"`  // 本次同步失败的内容数, 见 BackupItem.Err
+}
+
+// Backup 将用户的收藏夹同步到本地目录
+//
+// 目录结构:
+//
+//	<Dir>/backup.json          索引, 见 BackupStore
+//	<Dir>/covers/<bvid>.jpg    封面, 需开启 Covers
+//	<Dir>/media/<bvid>/...     DASH 音视频, 需开启 Media
+//
+// 备注：
+//   - 可重复运行, 每个收藏夹同步完成后保存索引
+//   - 已下载的封面与音视频不会重复下载
+type Backup struct {
+	Mid       int           // 用户 mid
+	Dir       string        // 备份目录
+	Folders   []int         // 仅备份这些收藏夹 mlid (可选), 默认为全部
+	Interval  time.Duration // 两次请求的最小间隔, 默认为1秒
+	Retries   int           // 单次请求最多尝试次数, 默认为3
+	RetryWait time.Duration // 首次重试前的等待时间, 默认为30秒
+	Enrich    bool          // 是否调用 Video.Info 获取分P cid, 默认为 true
+	Covers    bool          // 是否下载封面
+	Media     bool          // 是否下载音视频 (每个分P最高画质与音质的 m4s)
+	Quality   int           // 下载音视频时请求的清晰度, 默认为80
+
+	OnItem func(folder int, item BackupItem) // 每同步一条内容后回调 (可选)
+
+	folders   func() ([]FolderBrief, error)
+	resources func(mediaID, pn, ps int) ([]Media, bool, error)
+	info      func(bvid string) (*video.VideoData, error)
+	stream    func(aid int, bvid string, cid, qn int) (*video.StreamData, error)
+	download  func(ctx context.Context, url, dst string) error
+	now       func() time.Time
+	throttle  *misc.Throttle
+}
+
+// 创建收藏夹备份任务
+//
+// Parameters:
+//   - mid (int): 用户 mid
+//   - dir (string): 备份目录
+func (f *Fav) NewBackup(mid int, dir string) *Backup {
+	v := video.New(f.client)
+	b := &Backup{
+		Mid:       mid,
+		Dir:       dir,
+		Interval:  time.Second,
+		Retries:   3,
+		RetryWait: 30 * time.Second,
+		Enrich:    true,
+		Quality:   80,
+		now:       time.Now,
+	}
+	b.folders = func() ([]FolderBrief, error) {
+		resp, err := f.Folders(b.Mid, 0)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.List, nil
+	}
+	b.resources = func(mediaID, pn, ps int) ([]Media, bool, error) {
+		resp, err := f.Resources(mediaID, "", OrderMtime, false, pn, ps)
+		if err != nil {
+			return nil, false, err
+		}
+		if resp.Code != 0 {
+			return nil, false, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Medias, resp.Data.HasMore, nil
+	}
+	b.info = func(bvid string) (*video.VideoData, error) {
+		resp, err := v.Info(0, bvid)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return &resp.Data, nil
+	}
+	b.stream = func(aid int, bvid string, cid, qn int) (*video.StreamData, error) {
+		resp, err := v.Stream(aid, bvid, cid, qn)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 || resp.Data == nil {
+			return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data, nil
+	}
+	b.download = func(ctx context.Context, url, dst string) error {
+		resp, err := f.client.HTTPClient.R().
+			SetContext(ctx).
+			SetDoNotParseResponse(true).
+			SetHeader("Referer", "https://www.bilibili.com").
+			SetHeader("User-Agent", f.client.UserAgent).
+			Get(url)
+		if err != nil {
+			return err
+		}
+		body := resp.RawBody()
+		defer body.Close()
+		if resp.IsError() {
+			return fmt.Errorf("download %s: %s", url, resp.Status())
+		}
+		return writeFile(dst, body)
+	}
+	return b
+}
+
+// IsInvalid 收藏内容是否已失效
+func (m Media) IsInvalid() bool {
+	return m.Attr != 0 || m.Title == InvalidTitle
+}
+
+// Run 执行一次同步
+func (b *Backup) Run(ctx context.Context) (*BackupReport, error) {
+	b.throttle = &misc.Throttle{Interval: b.Interval}
+	report := &BackupReport{}
+
+	store, err := LoadBackupStore(b.Dir)
+	if err != nil {
+		return report, err
+	}
+	store.Mid = b.Mid
+
+	var folders []FolderBrief
+	if err := b.retry(ctx, func() (err error) {
+		folders, err = b.folders()
+		return err
+	}); err != nil {
+		return report, fmt.Errorf("list folders of %d: %w", b.Mid, err)
+	}
+
+	for _, folder := range folders {
+		if len(b.Folders) > 0 && !containsInt(b.Folders, folder.ID) {
+			continue
+		}
+		if err := b.syncFolder(ctx, store, folder, report); err != nil {
+			return report, fmt.Errorf("sync folder %d: %w", folder.ID, err)
+		}
+		report.Folders++
+		if err := store.save(b.Dir); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (b *Backup) syncFolder(ctx context.Context, store *BackupStore, folder FolderBrief, report *BackupReport) error {
+	bf := store.Folders[folder.ID]
+	if bf == nil {
+		bf = &BackupFolder{ID: folder.ID, Items: map[string]*BackupItem{}}
+		store.Folders[folder.ID] = bf
+	}
+	bf.Title = folder.Title
+	now := b.now().Unix()
+
+	pager := misc.NewPager(ctx, 20, 20, 0, func(pn, ps int) ([]Media, int, error) {
+		var medias []Media
+		var hasMore bool
+		err := b.retry(ctx, func() (err error) {
+			medias, hasMore, err = b.resources(folder.ID, pn, ps)
+			return err
+		})
+		return medias, pageTotal(pn, ps, len(medias), hasMore), err
+	})
+
+	seen := map[string]bool{}
+	for pager.Next() {
+		m := pager.Item()
+		key := m.Resource().String()
+		seen[key] = true
+
+		item := bf.Items[key]
+		if item == nil {
+			item = &BackupItem{ID: m.ID, Type: m.Type}
+			bf.Items[key] = item
+			report.Added++
+		}
+		item.RemovedAt = 0
+		item.FavTime = m.FavTime
+		if item.Bvid == "" {
+			item.Bvid = firstNonEmpty(m.Bvid, m.BvID)
+		}
+
+		if m.IsInvalid() {
+			if !item.Invalid {
+				item.Invalid, item.InvalidAt = true, now
+				report.Invalid++
+			}
+			if item.Title == "" {
+				// 首次备份时即已失效, 只能保留接口返回的信息
+				item.Title = m.Title
+			}
+		} else {
+			item.Invalid, item.InvalidAt = false, 0
+			prev := *item
+			if err := b.refresh(ctx, item, m); err != nil {
+				// 风控与取消时中止, 稿件不可见等单条内容的错误只记录并继续
+				if ctx.Err() != nil || misc.IsRetryable(err) {
+					return fmt.Errorf("refresh %s: %w", key, err)
+				}
+				*item = prev
+				item.Err = err.Error()
+				report.Failed++
+			} else {
+				item.Err = ""
+				item.SyncedAt = now
+			}
+		}
+		report.Items++
+		if b.OnItem != nil {
+			b.OnItem(folder.ID, *item)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	for key, item := range bf.Items {
+		if !seen[key] && item.RemovedAt == 0 {
+			item.RemovedAt = now
+			report.Removed++
+		}
+	}
+	bf.SyncedAt = now
+	return nil
+}
+
+// 更新有效内容的元数据, 并按需下载封面与音视频
+func (b *Backup) refresh(ctx context.Context, item *BackupItem, m Media) error {
+	pageChanged := item.Page != m.Page
+	item.Title = m.Title
+	item.Intro = m.Intro
+	item.Cover = m.Cover
+	item.UpperMid = m.Upper.Mid
+	item.UpperName = m.Upper.Name
+	item.Duration = m.Duration
+	item.Page = m.Page
+	item.Pubtime = m.Pubtime
+
+	if m.Type != ResourceVideo || item.Bvid == "" {
+		return nil
+	}
+	if b.Enrich && (len(item.Cids) == 0 || pageChanged) {
+		var data *video.VideoData
+		if err := b.retry(ctx, func() (err error) {
+			data, err = b.info(item.Bvid)
+			return err
+		}); err != nil {
+			return err
+		}
+		item.Intro = data.Desc
+		item.Cids = item.Cids[:0]
+		for _, p := range data.Pages {
+			item.Cids = append(item.Cids, p.CID)
+		}
+	}
+
+	if b.Covers && item.Cover != "" && !b.exists(item.CoverFile) {
+		ext := path.Ext(item.Cover)
+		if ext == "" {
+			ext = ".jpg"
+		}
+		file := filepath.Join("covers", item.Bvid+ext)
+		if err := b.retry(ctx, func() error {
+			return b.download(ctx, item.Cover, filepath.Join(b.Dir, file))
+		}); err != nil {
+			return err
+		}
+		item.CoverFile = file
+	}
+
+	if b.Media && len(item.MediaFiles) == 0 {
+		files, err := b.downloadMedia(ctx, item)
+		if err != nil {
+			return err
+		}
+		item.MediaFiles = files
+	}
+	return nil
+}
+
+// 下载每个分P最高带宽的视频流与伴音流
+func (b *Backup) downloadMedia(ctx context.Context, item *BackupItem) ([]string, error) {
+	var files []string
+	for _, cid := range item.Cids {
+		var data *video.StreamData
+		if err := b.retry(ctx, func() (err error) {
+			data, err = b.stream(item.ID, item.Bvid, cid, b.Quality)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		videos, audios := video.DashStreams(data.Dash)
+		for i, streams := range [][]video.Stream{videos, audios} {
+			kind := [...]string{"video", "audio"}[i]
+			best, ok := bestStream(streams)
+			if !ok {
+				continue
+			}
+			file := filepath.Join("media", item.Bvid, fmt.Sprintf("%d.%s.m4s", cid, kind))
+			if err := b.fetchStream(ctx, best, filepath.Join(b.Dir, file)); err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// 依次尝试主地址与备用地址
+func (b *Backup) fetchStream(ctx context.Context, s video.Stream, dst string) error {
+	var err error
+	for _, u := range append([]string{s.URL()}, s.BackupURLs()...) {
+		if u == "" {
+			continue
+		}
+		if err = b.download(ctx, u, dst); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	if err == nil {
+		err = errors.New("no stream url")
+	}
+	return err
+}
+
+func bestStream(streams []video.Stream) (video.Stream, bool) {
+	if len(streams) == 0 {
+		return video.Stream{}, false
+	}
+	best := streams[0]
+	for _, s := range streams[1:] {
+		if s.Bandwidth > best.Bandwidth {
+			best = s
+		}
+	}
+	return best, true
+}
+
+func (b *Backup) exists(file string) bool {
+	if file == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(b.Dir, file))
+	return err == nil
+}
+
+func (b *Backup) retry(ctx context.Context, fn func() error) error {
+	return misc.Retry(ctx, b.Retries, b.RetryWait, func() error {
+		if err := b.throttle.Wait(ctx); err != nil {
+			return err
+		}
+		return fn()
+	})
+}
+
+// 读取备份目录中的索引, 不存在时返回空索引
+//
+// Parameters:
+//   - dir (string): 备份目录
+func LoadBackupStore(dir string) (*BackupStore, error) {
+	store := &BackupStore{Folders: map[int]*BackupFolder{}}
+	b, err := os.ReadFile(filepath.Join(dir, "backup.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, store); err != nil {
+		return nil, fmt.Errorf("invalid backup index: %w", err)
+	}
+	if store.Folders == nil {
+		store.Folders = map[int]*BackupFolder{}
+	}
+	for _, f := range store.Folders {
+		if f.Items == nil {
+			f.Items = map[string]*BackupItem{}
+		}
+	}
+	return store, nil
+}
+
+// InvalidItems 返回全部已失效的内容
+func (s *BackupStore) InvalidItems() []BackupItem {
+	var items []BackupItem
+	for _, f := range s.Folders {
+		for _, item := range f.Items {
+			if item.Invalid {
+				items = append(items, *item)
+			}
+		}
+	}
+	return items
+}
+
+func (s *BackupStore) save(dir string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, "backup.json"), bytes.NewReader(b))
+}
+
+// 先写临时文件再替换, 避免中断时留下不完整的文件
+func writeFile(dst string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp := dst + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
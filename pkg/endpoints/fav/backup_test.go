@@ -0,0 +1,144 @@
+package fav
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/video"
+	"github.com/Yuelioi/bilibili/pkg/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackup(t *testing.T) {
+	dir := t.TempDir()
+	medias := []Media{
+		{ID: 1, Type: ResourceVideo, Bvid: "BV1", Title: "视频一", Cover: "http://i0.hdslb.com/a.png", Page: 2, Upper: Upper{Mid: 10, Name: "UP"}},
+		{ID: 2, Type: ResourceVideo, Bvid: "BV2", Title: "视频二", Page: 1},
+	}
+	infoCalls := 0
+	var downloads []string
+
+	b := &Backup{
+		Mid:     7,
+		Dir:     dir,
+		Retries: 1,
+		Enrich:  true,
+		Covers:  true,
+		now:     func() time.Time { return time.Unix(1700000000, 0) },
+		folders: func() ([]FolderBrief, error) {
+			return []FolderBrief{{ID: 100, Title: "默认收藏夹"}, {ID: 200, Title: "跳过"}}, nil
+		},
+		resources: func(mediaID, pn, ps int) ([]Media, bool, error) {
+			assert.Equal(t, 100, mediaID)
+			return medias, false, nil
+		},
+		info: func(bvid string) (*video.VideoData, error) {
+			infoCalls++
+			return &video.VideoData{Desc: bvid + " 简介", Pages: []video.Page{{CID: 11}, {CID: 12}}}, nil
+		},
+		download: func(ctx context.Context, url, dst string) error {
+			downloads = append(downloads, url)
+			return writeFile(dst, strings.NewReader("img"))
+		},
+		Folders: []int{100},
+	}
+
+	report, err := b.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &BackupReport{Folders: 1, Items: 2, Added: 2}, report)
+	assert.Equal(t, 2, infoCalls)
+	assert.Equal(t, []string{"http://i0.hdslb.com/a.png"}, downloads)
+	_, err = os.Stat(filepath.Join(dir, "covers", "BV1.png"))
+	assert.NoError(t, err)
+
+	// 视频一失效, 视频二被移出收藏夹
+	medias = []Media{{ID: 1, Type: ResourceVideo, Bvid: "BV1", Title: InvalidTitle, Attr: 9, Page: 1}}
+	b.now = func() time.Time { return time.Unix(1700000100, 0) }
+	report, err = b.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &BackupReport{Folders: 1, Items: 1, Invalid: 1, Removed: 1}, report)
+	assert.Equal(t, 2, infoCalls)
+	assert.Len(t, downloads, 1)
+
+	store, err := LoadBackupStore(dir)
+	assert.NoError(t, err)
+	invalid := store.InvalidItems()
+	assert.Len(t, invalid, 1)
+	assert.Equal(t, "视频一", invalid[0].Title)
+	assert.Equal(t, "BV1 简介", invalid[0].Intro)
+	assert.Equal(t, []int{11, 12}, invalid[0].Cids)
+	assert.Equal(t, int64(1700000100), invalid[0].InvalidAt)
+	assert.Equal(t, int64(1700000000), invalid[0].SyncedAt)
+	assert.Equal(t, int64(1700000100), store.Folders[100].Items["2:2"].RemovedAt)
+}
+
+func TestBackupItemError(t *testing.T) {
+	dir := t.TempDir()
+	medias := []Media{
+		{ID: 1, Type: ResourceVideo, Bvid: "BV1", Title: "视频一", Page: 1},
+		{ID: 2, Type: ResourceVideo, Bvid: "BV2", Title: "视频二", Page: 1},
+	}
+	hidden := map[string]int{}
+	b := &Backup{
+		Mid:     7,
+		Dir:     dir,
+		Retries: 1,
+		Enrich:  true,
+		now:     func() time.Time { return time.Unix(1700000000, 0) },
+		folders: func() ([]FolderBrief, error) {
+			return []FolderBrief{{ID: 100}, {ID: 200}}, nil
+		},
+		resources: func(mediaID, pn, ps int) ([]Media, bool, error) {
+			return medias, false, nil
+		},
+		info: func(bvid string) (*video.VideoData, error) {
+			if code := hidden[bvid]; code != 0 {
+				return nil, &misc.CodeError{Code: code, Message: "稿件不可见"}
+			}
+			return &video.VideoData{Desc: bvid + " 简介", Pages: []video.Page{{CID: 11}}}, nil
+		},
+	}
+
+	_, err := b.Run(context.Background())
+	assert.NoError(t, err)
+
+	// 视频一变为仅 UP 主可见, 收藏夹中 attr 仍为 0, 且分P数变化需重新获取
+	hidden["BV1"] = 62012
+	medias[0].Page, medias[0].Title = 2, "视频一改"
+	b.now = func() time.Time { return time.Unix(1700000100, 0) }
+	report, err := b.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &BackupReport{Folders: 2, Items: 4, Failed: 2}, report)
+
+	store, err := LoadBackupStore(dir)
+	assert.NoError(t, err)
+	for _, id := range []int{100, 200} {
+		item := store.Folders[id].Items["1:2"]
+		assert.Contains(t, item.Err, "62012")
+		assert.Equal(t, "视频一", item.Title)
+		assert.Equal(t, "BV1 简介", item.Intro)
+		assert.Equal(t, []int{11}, item.Cids)
+		assert.Equal(t, int64(1700000000), item.SyncedAt)
+		assert.Equal(t, int64(1700000100), store.Folders[id].SyncedAt)
+	}
+
+	// 恢复后清除错误
+	delete(hidden, "BV1")
+	report, err = b.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, report.Failed)
+	store, err = LoadBackupStore(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, store.Folders[100].Items["1:2"].Err)
+	assert.Equal(t, "视频一改", store.Folders[100].Items["1:2"].Title)
+
+	// 风控错误仍中止同步
+	hidden["BV2"] = -412
+	medias[1].Page = 3
+	_, err = b.Run(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,11 @@
+package fav
+
+import "github.com/Yuelioi/bilibili/pkg/client"
+
+type Fav struct {
+	client *client.Client
+}
+
+func New(client *client.Client) *Fav {
+	return &Fav{client}
+}
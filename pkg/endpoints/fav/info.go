@@ -0,0 +1,389 @@
+package fav
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 收藏内容排序方式
+const (
+	OrderMtime   = "mtime"   // 最近收藏
+	OrderView    = "view"    // 最多播放
+	OrderPubtime = "pubtime" // 最近投稿
+)
+
+// 获取用户创建的全部收藏夹
+//
+// Parameters:
+//   - upMid (int): 目标用户 mid
+//   - rid (int): 稿件 avid (可选), 用于在 FavState 中返回该稿件是否在各收藏夹中
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 查询私密收藏夹时必要
+func (f *Fav) Folders(upMid int, rid int) (*FoldersResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v3/fav/folder/created/list-all"
+
+	formData := map[string]string{
+		"up_mid": fmt.Sprintf("%d", upMid),
+		"type":   "2",
+	}
+	if rid != 0 {
+		formData["rid"] = fmt.Sprintf("%d", rid)
+	}
+
+	resp, err := f.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&FoldersResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*FoldersResponse), nil
+}
+
+// 获取收藏夹元数据
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 查询私密收藏夹时必要
+func (f *Fav) FolderInfo(mediaID int) (*FolderInfoResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v3/fav/folder/info"
+
+	resp, err := f.client.HTTPClient.R().
+		SetQueryParam("media_id", fmt.Sprintf("%d", mediaID)).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&FolderInfoResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*FolderInfoResponse), nil
+}
+
+// 获取用户收藏的收藏夹/合集
+//
+// Parameters:
+//   - upMid (int): 目标用户 mid
+//   - pn (int): 页码, 默认为1
+//   - ps (int): 每页项数, 默认为20, 最大为70
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+func (f *Fav) CollectedFolders(upMid int, pn, ps int) (*CollectedFoldersResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v3/fav/folder/collected/list"
+
+	if pn == 0 {
+		pn = 1
+	}
+	if ps == 0 {
+		ps = 20
+	}
+
+	formData := map[string]string{
+		"up_mid":   fmt.Sprintf("%d", upMid),
+		"pn":       fmt.Sprintf("%d", pn),
+		"ps":       fmt.Sprintf("%d", ps),
+		"platform": "web",
+	}
+
+	resp, err := f.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&CollectedFoldersResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*CollectedFoldersResponse), nil
+}
+
+// 获取收藏夹内容明细列表
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//   - keyword (string): 搜索关键词 (可选)
+//   - order (string): 排序方式, 见 OrderMtime 等, 默认为 mtime
+//   - all (bool): 搜索范围, false: 当前收藏夹, true: 全部收藏夹, 仅在 keyword 非空时有效
+//   - pn (int): 页码, 默认为1
+//   - ps (int): 每页项数, 默认为20, 最大为20
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 查询私密收藏夹时必要
+func (f *Fav) Resources(mediaID int, keyword, order string, all bool, pn, ps int) (*ResourcesResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v3/fav/resource/list"
+
+	if pn == 0 {
+		pn = 1
+	}
+	if ps == 0 {
+		ps = 20
+	}
+	if order == "" {
+		order = OrderMtime
+	}
+
+	formData := map[string]string{
+		"media_id": fmt.Sprintf("%d", mediaID),
+		"keyword":  keyword,
+		"order":    order,
+		"type":     "0",
+		"tid":      "0",
+		"pn":       fmt.Sprintf("%d", pn),
+		"ps":       fmt.Sprintf("%d", ps),
+		"platform": "web",
+	}
+	if all {
+		formData["type"] = "1"
+	}
+
+	resp, err := f.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&ResourcesResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ResourcesResponse), nil
+}
+
+// 遍历收藏夹内容
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - mediaID (int): 收藏夹 mlid
+//   - keyword (string): 搜索关键词 (可选)
+//   - order (string): 排序方式, 见 OrderMtime 等
+//   - pageSize (int): 每页项数, 最大为20
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (f *Fav) ResourcesIter(ctx context.Context, mediaID int, keyword, order string, pageSize, maxItems int) *misc.Pager[Media] {
+	return misc.NewPager(ctx, pageSize, 20, maxItems, func(pn, ps int) ([]Media, int, error) {
+		resp, err := f.Resources(mediaID, keyword, order, false, pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Medias, pageTotal(pn, ps, len(resp.Data.Medias), resp.Data.HasMore), nil
+	})
+}
+
+// 按 has_more 推算总数: 没有更多时返回已取得的项数, 否则返回0 (未知)
+func pageTotal(pn, ps, n int, hasMore bool) int {
+	if hasMore {
+		return 0
+	}
+	return (pn-1)*ps + n
+}
+
+// 获取收藏夹全部内容 id
+//
+// Parameters:
+//   - mediaID (int): 收藏夹 mlid
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 查询私密收藏夹时必要
+//
+// 备注：
+//   - 不分页, 可用于快速比对或批量操作
+func (f *Fav) ResourceIDs(mediaID int) (*ResourceIDsResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v3/fav/resource/ids"
+
+	formData := map[string]string{
+		"media_id": fmt.Sprintf("%d", mediaID),
+		"platform": "web",
+	}
+
+	resp, err := f.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: f.client.SESSDATA,
+		}).
+		SetResult(&ResourceIDsResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ResourceIDsResponse), nil
+}
+
+// FoldersResponse 用户创建的收藏夹列表
+type FoldersResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Count  int           `json:"count"`  // 收藏夹总数
+		List   []FolderBrief `json:"list"`   // 收藏夹列表
+		Season interface{}   `json:"season"` // 作用尚不明确
+	} `json:"data"` // 数据本体, 用户没有收藏夹时为 null
+}
+
+// FolderBrief 收藏夹简要信息
+type FolderBrief struct {
+	ID         int    `json:"id"`          // 收藏夹 mlid
+	Fid        int    `json:"fid"`         // 原始收藏夹 id
+	Mid        int    `json:"mid"`         // 创建者 mid
+	Attr       int    `json:"attr"`        // 属性位, bit0: 0公开 1私密, bit1: 0默认收藏夹 1其他
+	Title      string `json:"title"`       // 收藏夹标题
+	FavState   int    `json:"fav_state"`   // 请求时带 rid 时, 该稿件是否在此收藏夹中, 0: 否, 1: 是
+	MediaCount int    `json:"media_count"` // 内容数量
+}
+
+// Private 是否为私密收藏夹
+func (b FolderBrief) Private() bool {
+	return b.Attr&1 == 1
+}
+
+// Default 是否为默认收藏夹
+func (b FolderBrief) Default() bool {
+	return b.Attr&2 == 0
+}
+
+// FolderInfoResponse 收藏夹元数据
+type FolderInfoResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -403表示访问权限不足
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    Folder `json:"data"`    // 收藏夹元数据
+}
+
+// Folder 收藏夹元数据
+type Folder struct {
+	ID        int    `json:"id"`         // 收藏夹 mlid
+	Fid       int    `json:"fid"`        // 原始收藏夹 id
+	Mid       int    `json:"mid"`        // 创建者 mid
+	Attr      int    `json:"attr"`       // 属性位, 见 FolderBrief
+	Title     string `json:"title"`      // 收藏夹标题
+	Cover     string `json:"cover"`      // 收藏夹封面
+	Upper     Upper  `json:"upper"`      // 创建者信息
+	CoverType int    `json:"cover_type"` // 封面图类别, 2: 视频封面
+	CntInfo   struct {
+		Collect int `json:"collect"`  // 收藏数
+		Play    int `json:"play"`     // 播放数
+		ThumbUp int `json:"thumb_up"` // 点赞数
+		Share   int `json:"share"`    // 分享数
+	} `json:"cnt_info"` // 状态数
+	Type       int    `json:"type"`        // 类型, 一般为11
+	Intro      string `json:"intro"`       // 备注
+	Ctime      int    `json:"ctime"`       // 创建时间
+	Mtime      int    `json:"mtime"`       // 修改时间
+	State      int    `json:"state"`       // 状态, 一般为0
+	FavState   int    `json:"fav_state"`   // 当前用户是否收藏了该收藏夹, 0: 否, 1: 是
+	LikeState  int    `json:"like_state"`  // 当前用户是否点赞了该收藏夹, 0: 否, 1: 是
+	MediaCount int    `json:"media_count"` // 内容数量
+	IsTop      bool   `json:"is_top"`      // 作用尚不明确
+}
+
+// Upper 创建者/UP 主信息
+type Upper struct {
+	Mid       int    `json:"mid"`        // 用户 mid
+	Name      string `json:"name"`       // 昵称
+	Face      string `json:"face"`       // 头像
+	Followed  bool   `json:"followed"`   // 当前用户是否已关注
+	VipType   int    `json:"vip_type"`   // 会员类型, 0: 无, 1: 月度, 2: 年度及以上
+	VipStatue int    `json:"vip_statue"` // 会员开通状态 (原文拼写), 0: 无, 1: 有
+	JumpLink  string `json:"jump_link"`  // 作用尚不明确
+}
+
+// CollectedFoldersResponse 用户收藏的收藏夹/合集列表
+type CollectedFoldersResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Count   int      `json:"count"`    // 总数
+		List    []Folder `json:"list"`     // 收藏夹列表, type 为21时是合集
+		HasMore bool     `json:"has_more"` // 是否还有更多
+	} `json:"data"` // 数据本体
+}
+
+// ResourcesResponse 收藏夹内容明细列表
+type ResourcesResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -403表示访问权限不足
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Info    Folder  `json:"info"`     // 收藏夹元数据
+		Medias  []Media `json:"medias"`   // 内容列表, 无内容时为 null
+		HasMore bool    `json:"has_more"` // 是否还有更多
+		TTL     int     `json:"ttl"`      // 接收时间戳
+	} `json:"data"` // 数据本体
+}
+
+// Media 收藏夹中的内容
+type Media struct {
+	ID       int    `json:"id"`       // 内容 id, 视频稿件为 avid, 音频为 auid, 合集为合集 id
+	Type     int    `json:"type"`     // 内容类型, 见 ResourceVideo 等
+	Title    string `json:"title"`    // 标题
+	Cover    string `json:"cover"`    // 封面
+	Intro    string `json:"intro"`    // 简介
+	Page     int    `json:"page"`     // 视频分P数
+	Duration int    `json:"duration"` // 时长, 单位为秒
+	Upper    Upper  `json:"upper"`    // UP 主信息
+	Attr     int    `json:"attr"`     // 失效标志, 0: 正常, 1: 其他原因删除, 9: UP 主自己删除
+	CntInfo  struct {
+		Collect    int    `json:"collect"`     // 收藏数
+		Play       int    `json:"play"`        // 播放数
+		Danmaku    int    `json:"danmaku"`     // 弹幕数
+		VT         int    `json:"vt"`          // 作用尚不明确
+		PlaySwitch int    `json:"play_switch"` // 作用尚不明确
+		Reply      int    `json:"reply"`       // 评论数
+		ViewText1  string `json:"view_text_1"` // 播放数文本
+	} `json:"cnt_info"` // 状态数
+	Link    string      `json:"link"`     // 跳转 uri
+	Ctime   int         `json:"ctime"`    // 投稿时间
+	Pubtime int         `json:"pubtime"`  // 发布时间
+	FavTime int         `json:"fav_time"` // 收藏时间
+	BvID    string      `json:"bv_id"`    // 视频 bvid
+	Bvid    string      `json:"bvid"`     // 视频 bvid
+	Season  interface{} `json:"season"`   // 剧集信息, 非剧集时为 null
+	Ogv     interface{} `json:"ogv"`      // 番剧信息, 非番剧时为 null
+	Ugc     struct {
+		FirstCid int `json:"first_cid"` // 视频1P cid
+	} `json:"ugc"` // 视频信息
+	MediaListLink string `json:"media_list_link"` // 播放列表跳转 uri
+}
+
+// Resource 返回用于批量操作的内容标识
+func (m Media) Resource() Resource {
+	return Resource{ID: m.ID, Type: m.Type}
+}
+
+// ResourceIDsResponse 收藏夹全部内容 id
+type ResourceIDsResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -403表示访问权限不足
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    []struct {
+		ID   int    `json:"id"`    // 内容 id
+		Type int    `json:"type"`  // 内容类型, 见 ResourceVideo 等
+		BvID string `json:"bv_id"` // 视频 bvid
+		Bvid string `json:"bvid"`  // 视频 bvid
+	} `json:"data"` // 内容列表
+}
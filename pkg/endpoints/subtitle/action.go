@@ -0,0 +1,135 @@
+package subtitle
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/video"
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 获取视频可用的字幕列表
+//
+// Parameters:
+//   - aid (int): 视频的 aid (可选)
+//   - bvid (string): 视频的 bvid (可选)
+//   - cid (int): 视频的 cid (必要)
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 未登录时字幕列表为空
+//   - 鉴权方式：Wbi 签名
+func (s *Subtitle) List(aid int, bvid string, cid int) ([]Track, error) {
+	resp, err := video.New(s.client).PlayerInfo(aid, bvid, cid)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+	}
+	if resp.Data.Subtitle == nil {
+		return nil, nil
+	}
+
+	tracks := make([]Track, 0, len(resp.Data.Subtitle.Subtitles))
+	for _, item := range resp.Data.Subtitle.Subtitles {
+		tracks = append(tracks, Track{
+			ID:       item.ID,
+			Lan:      item.Lan,
+			LanDoc:   item.LanDoc,
+			URL:      normalizeURL(item.SubtitleURL),
+			AIType:   item.AIType,
+			AIStatus: item.AIStatus,
+		})
+	}
+	return tracks, nil
+}
+
+// 下载 BCC 格式字幕
+//
+// Parameters:
+//   - url (string): 字幕资源地址, 见 Track.URL
+func (s *Subtitle) Download(url string) (*BCC, error) {
+	if url == "" {
+		return nil, fmt.Errorf("subtitle url is empty")
+	}
+
+	resp, err := s.client.HTTPClient.R().
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetHeader("User-Agent", s.client.UserAgent).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: s.client.SESSDATA,
+		}).
+		SetResult(&BCC{}).
+		ForceContentType("application/json").
+		Get(normalizeURL(url))
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("request failed with status: %s", resp.Status())
+	}
+
+	return resp.Result().(*BCC), nil
+}
+
+// 按语言下载字幕, lan 与 Track.Lan 一致, 如 zh-CN、ai-zh
+func (s *Subtitle) DownloadLan(aid int, bvid string, cid int, lan string) (*BCC, error) {
+	tracks, err := s.List(aid, bvid, cid)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tracks {
+		if t.Lan == lan {
+			return s.Download(t.URL)
+		}
+	}
+	return nil, fmt.Errorf("subtitle language %q not found", lan)
+}
+
+// 字幕地址常为 //aisubtitle.hdslb.com/... 形式, 补全协议
+func normalizeURL(url string) string {
+	if strings.HasPrefix(url, "//") {
+		return "https:" + url
+	}
+	return url
+}
+
+// Track 单条字幕轨道
+type Track struct {
+	ID       int    `json:"id"`        // 字幕 ID
+	Lan      string `json:"lan"`       // 语言类型英文字母缩写
+	LanDoc   string `json:"lan_doc"`   // 语言类型中文名称
+	URL      string `json:"url"`       // BCC 字幕地址
+	AIType   int    `json:"ai_type"`   // AI 类型, 0: 非 AI 字幕
+	AIStatus int    `json:"ai_status"` // AI 状态, 0: 非 AI 字幕
+}
+
+// IsAI 是否为 AI 生成字幕
+func (t Track) IsAI() bool {
+	return t.AIType != 0 || t.AIStatus != 0 || strings.HasPrefix(t.Lan, "ai-")
+}
+
+// BCC B站 json 字幕格式
+type BCC struct {
+	FontSize        float64   `json:"font_size"`        // 字体大小, 相对值
+	FontColor       string    `json:"font_color"`       // 字体颜色, 如 #FFFFFF
+	BackgroundAlpha float64   `json:"background_alpha"` // 背景不透明度
+	BackgroundColor string    `json:"background_color"` // 背景颜色
+	Stroke          string    `json:"Stroke"`           // 描边
+	Type            string    `json:"type"`             // 字幕类型, 如 AIsubtitle
+	Lang            string    `json:"lang"`             // 语言
+	Version         string    `json:"version"`          // 版本
+	Body            []BCCLine `json:"body"`             // 字幕内容
+}
+
+// BCCLine 单条字幕
+type BCCLine struct {
+	From     float64 `json:"from"`     // 开始时间, 单位为秒
+	To       float64 `json:"to"`       // 结束时间, 单位为秒
+	Sid      int     `json:"sid"`      // 序号
+	Location int     `json:"location"` // 位置, 2 为底部
+	Content  string  `json:"content"`  // 内容
+	Music    float64 `json:"music"`    // 是否为音乐
+}
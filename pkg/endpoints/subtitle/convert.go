@@ -0,0 +1,199 @@
+package subtitle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ASSOptions ASS 字幕样式
+type ASSOptions struct {
+	PlayResX  int    // 画布宽度, 默认 1920
+	PlayResY  int    // 画布高度, 默认 1080
+	FontName  string // 字体, 默认 Microsoft YaHei
+	FontSize  int    // 字号, 默认 PlayResY/18
+	MarginV   int    // 底部边距, 默认 PlayResY/20
+	Secondary int    // 双语字幕中第二行字号, 0 表示与主字幕相同
+}
+
+// SRT 转换为 SRT 格式
+func (b *BCC) SRT() string {
+	var sb strings.Builder
+	for i, line := range b.sorted() {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			i+1,
+			formatTimestamp(line.From, ","),
+			formatTimestamp(line.To, ","),
+			line.Content)
+	}
+	return sb.String()
+}
+
+// WebVTT 转换为 WebVTT 格式
+func (b *BCC) WebVTT() string {
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n\n")
+	for i, line := range b.sorted() {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			i+1,
+			formatTimestamp(line.From, "."),
+			formatTimestamp(line.To, "."),
+			line.Content)
+	}
+	return sb.String()
+}
+
+// ASS 转换为 ASS 格式, opts 为 nil 时使用默认样式
+func (b *BCC) ASS(opts *ASSOptions) string {
+	o := ASSOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PlayResX <= 0 {
+		o.PlayResX = 1920
+	}
+	if o.PlayResY <= 0 {
+		o.PlayResY = 1080
+	}
+	if o.FontName == "" {
+		o.FontName = "Microsoft YaHei"
+	}
+	if o.FontSize <= 0 {
+		o.FontSize = o.PlayResY / 18
+	}
+	if o.MarginV <= 0 {
+		o.MarginV = o.PlayResY / 20
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[Script Info]\n")
+	sb.WriteString("ScriptType: v4.00+\n")
+	fmt.Fprintf(&sb, "PlayResX: %d\n", o.PlayResX)
+	fmt.Fprintf(&sb, "PlayResY: %d\n", o.PlayResY)
+	sb.WriteString("WrapStyle: 0\n")
+	sb.WriteString("ScaledBorderAndShadow: yes\n\n")
+
+	sb.WriteString("[V4+ Styles]\n")
+	sb.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	fmt.Fprintf(&sb, "Style: Default,%s,%d,&H00FFFFFF,&H000000FF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,2,0,2,20,20,%d,1\n\n",
+		o.FontName, o.FontSize, o.MarginV)
+
+	sb.WriteString("[Events]\n")
+	sb.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+	for _, line := range b.sorted() {
+		text := escapeASS(line.Content)
+		if o.Secondary > 0 {
+			// 双语字幕第二行使用较小字号
+			if i := strings.Index(text, `\N`); i >= 0 {
+				text = text[:i] + fmt.Sprintf(`\N{\fs%d}`, o.Secondary) + text[i+2:]
+			}
+		}
+		fmt.Fprintf(&sb, "Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+			formatASSTimestamp(line.From),
+			formatASSTimestamp(line.To),
+			text)
+	}
+	return sb.String()
+}
+
+// 合并两种语言为双语字幕
+//
+// Parameters:
+//   - primary (*BCC): 主字幕, 时间轴以此为准
+//   - secondary (*BCC): 副字幕, 与主字幕时间重叠最多的条目合并到同一行下方
+//
+// 备注：
+//   - 无法匹配到主字幕的副字幕会按原时间轴单独保留
+func Merge(primary, secondary *BCC) *BCC {
+	merged := &BCC{}
+	if primary != nil {
+		*merged = *primary
+	}
+	if secondary != nil && merged.Lang != "" && secondary.Lang != "" {
+		merged.Lang = merged.Lang + "+" + secondary.Lang
+	}
+
+	lines := primary.sorted()
+	extra := make([][]string, len(lines))
+	var orphans []BCCLine
+
+	for _, sec := range secondary.sorted() {
+		best, bestOverlap := -1, 0.0
+		for i, line := range lines {
+			if line.From >= sec.To {
+				break
+			}
+			if overlap := overlapOf(line, sec); overlap > bestOverlap {
+				best, bestOverlap = i, overlap
+			}
+		}
+		if best < 0 {
+			orphans = append(orphans, sec)
+			continue
+		}
+		extra[best] = append(extra[best], sec.Content)
+	}
+
+	body := make([]BCCLine, 0, len(lines)+len(orphans))
+	for i, line := range lines {
+		if len(extra[i]) > 0 {
+			line.Content = line.Content + "\n" + strings.Join(extra[i], " ")
+		}
+		body = append(body, line)
+	}
+	body = append(body, orphans...)
+	sort.SliceStable(body, func(i, j int) bool { return body[i].From < body[j].From })
+	for i := range body {
+		body[i].Sid = i + 1
+	}
+	merged.Body = body
+	return merged
+}
+
+// 按开始时间排序后的字幕副本
+func (b *BCC) sorted() []BCCLine {
+	if b == nil {
+		return nil
+	}
+	lines := make([]BCCLine, len(b.Body))
+	copy(lines, b.Body)
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].From < lines[j].From })
+	return lines
+}
+
+func overlapOf(a, b BCCLine) float64 {
+	start, end := a.From, a.To
+	if b.From > start {
+		start = b.From
+	}
+	if b.To < end {
+		end = b.To
+	}
+	return end - start
+}
+
+// 秒数转 00:00:00,000 形式, sep 为毫秒分隔符
+func formatTimestamp(seconds float64, sep string) string {
+	ms := int(seconds*1000 + 0.5)
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// 秒数转 ASS 使用的 0:00:00.00 形式
+func formatASSTimestamp(seconds float64) string {
+	cs := int(seconds*100 + 0.5)
+	if cs < 0 {
+		cs = 0
+	}
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
+
+func escapeASS(s string) string {
+	s = strings.ReplaceAll(s, "{", `\{`)
+	s = strings.ReplaceAll(s, "}", `\}`)
+	s = strings.ReplaceAll(s, "\r\n", `\N`)
+	s = strings.ReplaceAll(s, "\n", `\N`)
+	return s
+}
@@ -0,0 +1,53 @@
+package subtitle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var zh = &BCC{
+	Lang: "zh",
+	Body: []BCCLine{
+		{From: 3.2, To: 5, Content: "第二句"},
+		{From: 0, To: 1.5, Content: "第一句"},
+	},
+}
+
+var en = &BCC{
+	Lang: "en",
+	Body: []BCCLine{
+		{From: 0.1, To: 1.4, Content: "first"},
+		{From: 3.3, To: 4.9, Content: "second"},
+		{From: 10, To: 11, Content: "orphan"},
+	},
+}
+
+func TestSRT(t *testing.T) {
+	srt := zh.SRT()
+	assert.True(t, strings.HasPrefix(srt, "1\n00:00:00,000 --> 00:00:01,500\n第一句\n\n"))
+	assert.Contains(t, srt, "2\n00:00:03,200 --> 00:00:05,000\n第二句\n")
+}
+
+func TestWebVTT(t *testing.T) {
+	vtt := zh.WebVTT()
+	assert.True(t, strings.HasPrefix(vtt, "WEBVTT\n\n"))
+	assert.Contains(t, vtt, "00:00:03.200 --> 00:00:05.000")
+}
+
+func TestASS(t *testing.T) {
+	ass := Merge(zh, en).ASS(&ASSOptions{Secondary: 30})
+	assert.Contains(t, ass, "PlayResY: 1080")
+	assert.Contains(t, ass, `Dialogue: 0,0:00:00.00,0:00:01.50,Default,,0,0,0,,第一句\N{\fs30}first`)
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(zh, en)
+	assert.Equal(t, "zh+en", merged.Lang)
+	assert.Len(t, merged.Body, 3)
+	assert.Equal(t, "第一句\nfirst", merged.Body[0].Content)
+	assert.Equal(t, "第二句\nsecond", merged.Body[1].Content)
+	assert.Equal(t, "orphan", merged.Body[2].Content)
+	assert.Equal(t, 3, merged.Body[2].Sid)
+}
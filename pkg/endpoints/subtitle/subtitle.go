@@ -0,0 +1,11 @@
+package subtitle
+
+import "github.com/Yuelioi/bilibili/pkg/client"
+
+type Subtitle struct {
+	client *client.Client
+}
+
+func New(client *client.Client) *Subtitle {
+	return &Subtitle{client}
+}
@@ -0,0 +1,286 @@
+package video
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// ArchiveRecord 爬取结果中的一行
+type ArchiveRecord struct {
+	Mid       int          `json:"mid"`             // UP 主 mid
+	Aid       int          `json:"aid"`             // 稿件 avid
+	Bvid      string       `json:"bvid"`            // 稿件 bvid
+	Archive   SpaceArchive `json:"archive"`         // 投稿列表中的信息
+	Info      *VideoData   `json:"info,omitempty"`  // 视频详细信息
+	Pages     []VideoPart  `json:"pages,omitempty"` // 分P列表
+	CrawledAt int64        `json:"crawled_at"`      // 爬取时间
+}
+
+// ArchiveCrawler 爬取用户的全部投稿, 逐条补全详细信息并追加写入 JSONL
+//
+// 备注：
+//   - 每取得一页投稿列表即写入该页的稿件, 列表进度缓存在 ListCache
+//   - 输出文件中已存在的 bvid 会被跳过, 被风控中断后重新运行即可续爬, 已取得的列表页不会重复请求
+//   - 全部写入后删除列表缓存, 下次运行重新获取列表以发现新投稿
+//   - 风控/限流错误按 Retries 次指数退避重试, 仍失败则返回错误
+type ArchiveCrawler struct {
+	Mid       int           // 目标用户 mid
+	Output    string        // 输出的 JSONL 文件路径
+	ListCache string        // 投稿列表缓存路径, 默认为 Output + ".list"
+	Order     string        // 排序方式, 见 SpaceOrderPubdate 等
+	Interval  time.Duration // 两次请求的最小间隔, 默认为1秒
+	Retries   int           // 单次请求最多尝试次数, 默认为3
+	RetryWait time.Duration // 首次重试前的等待时间, 默认为30秒
+	Enrich    bool          // 是否调用 Info/PageList 补全信息, 默认为 true
+	MaxItems  int           // 本次最多写入的稿件数, 0 表示不限制
+
+	OnRecord func(rec ArchiveRecord) // 每写入一条后回调 (可选)
+
+	list     func(pn, ps int) ([]SpaceArchive, int, error)
+	info     func(bvid string) (*VideoData, error)
+	pages    func(bvid string) ([]VideoPart, error)
+	throttle *misc.Throttle
+}
+
+// 投稿列表缓存
+type archiveList struct {
+	Pages    int            `json:"pages"`    // 已取得的页数
+	Count    int            `json:"count"`    // 接口返回的总稿件数
+	Complete bool           `json:"complete"` // 是否已取得全部列表
+	Archives []SpaceArchive `json:"archives"` // 已取得的稿件
+}
+
+// 创建投稿爬虫
+//
+// Parameters:
+//   - mid (int): 目标用户 mid
+//   - output (string): 输出的 JSONL 文件路径
+func (v *Video) NewArchiveCrawler(mid int, output string) *ArchiveCrawler {
+	c := &ArchiveCrawler{
+		Mid:       mid,
+		Output:    output,
+		Order:     SpaceOrderPubdate,
+		Interval:  time.Second,
+		Retries:   3,
+		RetryWait: 30 * time.Second,
+		Enrich:    true,
+	}
+	c.list = func(pn, ps int) ([]SpaceArchive, int, error) {
+		resp, err := v.SpaceArchives(c.Mid, "", 0, c.Order, pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.List.Vlist, resp.Data.Page.Count, nil
+	}
+	c.info = func(bvid string) (*VideoData, error) {
+		resp, err := v.Info(0, bvid)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return &resp.Data, nil
+	}
+	c.pages = func(bvid string) ([]VideoPart, error) {
+		resp, err := v.PageList(0, bvid)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data, nil
+	}
+	return c
+}
+
+// Run 开始爬取, 返回本次新写入的条数
+func (c *ArchiveCrawler) Run(ctx context.Context) (int, error) {
+	c.throttle = &misc.Throttle{Interval: c.Interval}
+	if c.ListCache == "" {
+		c.ListCache = c.Output + ".list"
+	}
+
+	done, partial, err := loadArchiveRecords(c.Output)
+	if err != nil {
+		return 0, err
+	}
+	list, err := c.loadList()
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(c.Output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if partial {
+		// 上次中断留下了不完整的行, 另起一行
+		if _, err := f.Write([]byte("\n")); err != nil {
+			return 0, err
+		}
+	}
+
+	written := 0
+	enc := json.NewEncoder(f)
+	// 写入一批稿件, 达到 MaxItems 时返回 false
+	write := func(archives []SpaceArchive) (bool, error) {
+		for _, a := range archives {
+			if c.MaxItems > 0 && written >= c.MaxItems {
+				return false, nil
+			}
+			if done[a.Bvid] {
+				continue
+			}
+
+			rec := ArchiveRecord{Mid: c.Mid, Aid: a.Aid, Bvid: a.Bvid, Archive: a}
+			if c.Enrich {
+				if err := c.enrich(ctx, &rec); err != nil {
+					return false, fmt.Errorf("enrich %s: %w", a.Bvid, err)
+				}
+			}
+			rec.CrawledAt = time.Now().Unix()
+
+			if err := enc.Encode(&rec); err != nil {
+				return false, err
+			}
+			done[a.Bvid] = true
+			written++
+			if c.OnRecord != nil {
+				c.OnRecord(rec)
+			}
+		}
+		return true, nil
+	}
+
+	// 先处理缓存中已取得的列表, 再逐页获取剩余列表
+	if more, err := write(list.Archives); err != nil || !more {
+		return written, err
+	}
+	for !list.Complete {
+		pn := list.Pages + 1
+		var page []SpaceArchive
+		var count int
+		if err := c.retry(ctx, func() (err error) {
+			page, count, err = c.list(pn, 50)
+			return err
+		}); err != nil {
+			return written, fmt.Errorf("list archives of %d: %w", c.Mid, err)
+		}
+		list.Pages, list.Count = pn, count
+		list.Archives = append(list.Archives, page...)
+		list.Complete = len(page) == 0 || len(list.Archives) >= count
+		if err := c.saveList(list); err != nil {
+			return written, err
+		}
+		if more, err := write(page); err != nil || !more {
+			return written, err
+		}
+	}
+
+	// 全部写入, 下次运行重新获取列表
+	if err := os.Remove(c.ListCache); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return written, err
+	}
+	return written, nil
+}
+
+func (c *ArchiveCrawler) enrich(ctx context.Context, rec *ArchiveRecord) error {
+	err := c.retry(ctx, func() (err error) {
+		rec.Info, err = c.info(rec.Bvid)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return c.retry(ctx, func() (err error) {
+		rec.Pages, err = c.pages(rec.Bvid)
+		return err
+	})
+}
+
+func (c *ArchiveCrawler) loadList() (*archiveList, error) {
+	list := &archiveList{}
+	b, err := os.ReadFile(c.ListCache)
+	if errors.Is(err, os.ErrNotExist) {
+		return list, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, list); err != nil {
+		return nil, fmt.Errorf("invalid archive list cache %s: %w", c.ListCache, err)
+	}
+	return list, nil
+}
+
+// 写入列表缓存, 先写临时文件再替换, 避免中断时损坏
+func (c *ArchiveCrawler) saveList(list *archiveList) error {
+	b, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	tmp := c.ListCache + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.ListCache)
+}
+
+// 限速并重试一次请求
+func (c *ArchiveCrawler) retry(ctx context.Context, fn func() error) error {
+	return misc.Retry(ctx, c.Retries, c.RetryWait, func() error {
+		if err := c.throttle.Wait(ctx); err != nil {
+			return err
+		}
+		return fn()
+	})
+}
+
+// 读取已写入的记录, 返回已完成的 bvid, 以及文件是否以不完整的行结尾
+//
+// 备注：
+//   - 中断时可能留下不完整的最后一行, 解析失败的行会被忽略并在之后重新爬取
+func loadArchiveRecords(path string) (done map[string]bool, partial bool, err error) {
+	done = map[string]bool{}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			var rec struct {
+				Bvid string `json:"bvid"`
+			}
+			if json.Unmarshal(line, &rec) == nil && rec.Bvid != "" {
+				done[rec.Bvid] = true
+			}
+		}
+		if err == io.EOF {
+			return done, len(line) > 0, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package video
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadArchiveRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archives.jsonl")
+
+	done, partial, err := loadArchiveRecords(path)
+	assert.NoError(t, err)
+	assert.Empty(t, done)
+	assert.False(t, partial)
+
+	// 第三行在写入时被中断
+	content := `{"bvid":"BV1"}` + "\n" + `{"bvid":"BV2"}` + "\n" + `{"bvid":"BV3","ar`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	done, partial, err = loadArchiveRecords(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"BV1": true, "BV2": true}, done)
+	assert.True(t, partial)
+}
+
+func TestFlexInt(t *testing.T) {
+	var a []SpaceArchive
+	err := json.Unmarshal([]byte(`[{"play":123},{"play":"--"},{"play":"45"}]`), &a)
+	assert.NoError(t, err)
+	assert.Equal(t, []FlexInt{123, 0, 45}, []FlexInt{a[0].Play, a[1].Play, a[2].Play})
+}
+
+func TestArchiveCrawlerResume(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "archives.jsonl")
+	var all []SpaceArchive
+	for i := 0; i < 120; i++ {
+		all = append(all, SpaceArchive{Aid: i, Bvid: fmt.Sprintf("BV%d", i)})
+	}
+
+	var listed []int
+	banned := "BV60"
+	c := New(nil).NewArchiveCrawler(7, output)
+	c.Interval, c.Retries = 0, 1
+	c.list = func(pn, ps int) ([]SpaceArchive, int, error) {
+		listed = append(listed, pn)
+		start := (pn - 1) * ps
+		if start > len(all) {
+			start = len(all)
+		}
+		end := start + ps
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[start:end], len(all), nil
+	}
+	c.info = func(bvid string) (*VideoData, error) {
+		if bvid == banned {
+			return nil, &misc.CodeError{Code: -412, Message: "请求被拦截"}
+		}
+		return &VideoData{BVID: bvid}, nil
+	}
+	c.pages = func(bvid string) ([]VideoPart, error) { return nil, nil }
+
+	// 第二页处理到一半时被风控, 已写入的稿件立即落盘
+	n, err := c.Run(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 60, n)
+	assert.Equal(t, []int{1, 2}, listed)
+
+	// 续爬时不重新请求已取得的列表页
+	banned = ""
+	n, err = c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 60, n)
+	assert.Equal(t, []int{1, 2, 3}, listed)
+	_, err = os.Stat(output + ".list")
+	assert.True(t, os.IsNotExist(err))
+
+	f, err := os.Open(output)
+	assert.NoError(t, err)
+	defer f.Close()
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var rec ArchiveRecord
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		assert.False(t, seen[rec.Bvid], rec.Bvid)
+		seen[rec.Bvid] = true
+	}
+	assert.Len(t, seen, 120)
+
+	// 全部完成后重新获取列表
+	n, err = c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, listed)
+}
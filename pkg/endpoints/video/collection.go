@@ -1,7 +1,12 @@
 package video
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
 )
 
 // 获取视频合集信息
@@ -225,6 +230,134 @@ func (v *Video) Archives(mid, seriesID int, sort string, pn, ps, currentMid int)
 	return resp.Result().(*SeriesArchivesResponse), nil
 }
 
+// 遍历视频合集中的全部视频
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - mid (int): 用户的mid
+//   - seasonID (int): 视频合集 ID
+//   - sortReverse (bool): 排序方式, true表示升序
+//   - pageSize (int): 单页内容数量, 最大为100
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (v *Video) SeasonsArchivesIter(ctx context.Context, mid int, seasonID int, sortReverse bool, pageSize, maxItems int) *misc.Pager[Archive] {
+	return misc.NewPager(ctx, pageSize, 100, maxItems, func(pn, ps int) ([]Archive, int, error) {
+		resp, err := v.SeasonsArchives(mid, seasonID, sortReverse, pn, ps, "", "", "", 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Archives, resp.Data.Page.Total, nil
+	})
+}
+
+// 遍历用户的全部系列
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - mid (int): 用户的 mid
+//   - pageSize (int): 单页内容数量, 最大为20
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (v *Video) SeasonsSeriesIter(ctx context.Context, mid int, pageSize, maxItems int) *misc.Pager[Series] {
+	return misc.NewPager(ctx, pageSize, 20, maxItems, func(pn, ps int) ([]Series, int, error) {
+		resp, err := v.SeasonsSeries(mid, pn, ps, "", "", 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		list := resp.Data.ItemsLists
+		return list.SeriesList, list.Page.Total, nil
+	})
+}
+
+// 遍历用户的全部合集与系列, 先返回全部合集再返回全部系列
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - mid (int): 用户的 mid
+//   - pageSize (int): 单页内容数量, 最大为20
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+//
+// 备注：
+//   - 接口每页分别返回至多 pageSize 个合集与系列, 因此按合集、系列两轮翻页, 游标形如 "seasons:2"
+func (v *Video) SeasonsSeriesListIter(ctx context.Context, mid int, pageSize, maxItems int) *misc.CursorPager[Series] {
+	if pageSize <= 0 || pageSize > 20 {
+		pageSize = 20
+	}
+	return misc.NewCursorPager(ctx, "", maxItems, seasonsSeriesListPages(pageSize, func(pn, ps int) (*SeasonsSeriesListResponse, error) {
+		return v.SeasonsSeriesList(mid, pn, ps, "", 0, "")
+	}))
+}
+
+// 合集与系列的游标翻页, 游标为 "seasons:页码" 或 "series:页码", 空游标为合集第一页
+func seasonsSeriesListPages(ps int, fetch func(pn, ps int) (*SeasonsSeriesListResponse, error)) misc.CursorFunc[Series] {
+	return func(cursor string) ([]Series, string, bool, error) {
+		kind, pn := "seasons", 1
+		if cursor != "" {
+			var page string
+			var ok bool
+			kind, page, ok = strings.Cut(cursor, ":")
+			n, err := strconv.Atoi(page)
+			if !ok || err != nil || (kind != "seasons" && kind != "series") {
+				return nil, "", false, fmt.Errorf("invalid cursor %q", cursor)
+			}
+			pn = n
+		}
+
+		for {
+			resp, err := fetch(pn, ps)
+			if err != nil {
+				return nil, "", false, err
+			}
+			if resp.Code != 0 {
+				return nil, "", false, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+			}
+
+			list := resp.Data.ItemsLists
+			items := list.SeriesList
+			if kind == "seasons" {
+				items = list.SeasonsList
+			}
+			if len(items) >= ps {
+				return items, fmt.Sprintf("%s:%d", kind, pn+1), false, nil
+			}
+			if kind == "series" {
+				return items, "", true, nil
+			}
+			// 合集已取完, 转到系列
+			if len(items) > 0 {
+				return items, "series:1", false, nil
+			}
+			kind, pn = "series", 1
+		}
+	}
+}
+
+// 遍历指定系列的全部视频
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - mid (int): 用户的 mid
+//   - seriesID (int): 系列 ID
+//   - sort (string): 排序方式, 可选值为 "desc" 或 "asc"
+//   - pageSize (int): 每页数量, 最大为100
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (v *Video) ArchivesIter(ctx context.Context, mid, seriesID int, sort string, pageSize, maxItems int) *misc.Pager[SeasonArchive] {
+	return misc.NewPager(ctx, pageSize, 100, maxItems, func(pn, ps int) ([]SeasonArchive, int, error) {
+		resp, err := v.Archives(mid, seriesID, sort, pn, ps, 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.Archives, resp.Data.Page.Total, nil
+	})
+}
+
 // SeasonArchivesResponse represents the structure of the API response for fetching season archives.
 type SeasonArchivesResponse struct {
 	Code    int    `json:"code"`    // 返回值: 0表示成功
@@ -1,8 +1,10 @@
 package video
 
 import (
+	"context"
 	"testing"
 
+	"github.com/Yuelioi/bilibili/pkg/misc"
 	"github.com/Yuelioi/bilibili/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -59,3 +61,47 @@ func TestArchives(t *testing.T) {
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, resp.Code, 0)
 }
+
+func TestSeasonsSeriesListPages(t *testing.T) {
+	// 3 个合集, 1 个系列, 每页2项
+	seasons := []Series{{Meta: SeriesMeta{Name: "s1"}}, {Meta: SeriesMeta{Name: "s2"}}, {Meta: SeriesMeta{Name: "s3"}}}
+	series := []Series{{Meta: SeriesMeta{Name: "r1"}}}
+	page := func(list []Series, pn, ps int) []Series {
+		start, end := (pn-1)*ps, pn*ps
+		if start > len(list) {
+			start = len(list)
+		}
+		if end > len(list) {
+			end = len(list)
+		}
+		return list[start:end]
+	}
+	var pages []int
+	fetch := func(pn, ps int) (*SeasonsSeriesListResponse, error) {
+		pages = append(pages, pn)
+		resp := &SeasonsSeriesListResponse{}
+		resp.Data.ItemsLists.SeasonsList = page(seasons, pn, ps)
+		resp.Data.ItemsLists.SeriesList = page(series, pn, ps)
+		resp.Data.ItemsLists.Page.Total = len(seasons) + len(series)
+		return resp, nil
+	}
+
+	items, err := misc.NewCursorPager(context.Background(), "", 0, seasonsSeriesListPages(2, fetch)).All()
+	assert.NoError(t, err)
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Meta.Name)
+	}
+	assert.Equal(t, []string{"s1", "s2", "s3", "r1"}, names)
+	assert.Equal(t, []int{1, 2, 1}, pages)
+
+	// 没有合集时直接转到系列
+	seasons, pages = nil, nil
+	items, err = misc.NewCursorPager(context.Background(), "", 0, seasonsSeriesListPages(2, fetch)).All()
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, []int{1, 1}, pages)
+
+	_, _, _, err = seasonsSeriesListPages(2, fetch)("bad")
+	assert.Error(t, err)
+}
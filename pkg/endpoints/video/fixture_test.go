@@ -0,0 +1,148 @@
+package video
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+	"github.com/Yuelioi/bilibili/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -run Fixture -update 从接口抓取真实响应并覆盖 testdata 中的样例
+var update = flag.Bool("update", false, "从接口抓取并覆盖 testdata 中的样例")
+
+// 请求 rawURL 并将格式化后的响应写入 testdata/name
+func captureFixture(t *testing.T, name, rawURL string) {
+	t.Helper()
+	tc := tests.NewTestClient().WithSessdata()
+	resp, err := tc.Client.HTTPClient.R().
+		SetHeader("User-Agent", tc.Client.UserAgent).
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: tc.Client.SESSDATA,
+		}).
+		Get(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, resp.Body(), "", "  "); err != nil {
+		t.Fatalf("capture %s: %v", name, err)
+	}
+	buf.WriteByte('\n')
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("testdata", name), buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 使用 DisallowUnknownFields 解析 testdata 中保存的接口样例,
+// 接口新增字段而结构体未跟进时测试失败.
+// 样例只能通过 -update 从接口抓取, 尚未抓取时跳过
+func decodeFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if os.IsNotExist(err) {
+		t.Skipf("testdata/%s 尚未抓取, 请运行 go test -run Fixture -update ./pkg/endpoints/video", name)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		t.Fatalf("decode %s: %v", name, err)
+	}
+}
+
+// 结构尚未确认的字段以 json.RawMessage 保存, 样例中出现内容时测试失败,
+// 提示补全对应的结构体
+func assertUntyped(t *testing.T, fields map[string]json.RawMessage) {
+	t.Helper()
+	for name, raw := range fields {
+		switch string(bytes.TrimSpace(raw)) {
+		case "", "null", "[]", "{}":
+		default:
+			t.Errorf("%s is no longer empty, type it: %s", name, raw)
+		}
+	}
+}
+
+func TestWebPlayerInfoFixture(t *testing.T) {
+	if *update {
+		tc := tests.NewTestClient().WithSessdata()
+		pages, err := New(tc.Client).PageList(aid, "")
+		if err != nil || len(pages.Data) == 0 {
+			t.Fatalf("page list: %v", err)
+		}
+		rawURL := fmt.Sprintf("https://api.bilibili.com/x/player/wbi/v2?aid=%d&cid=%d", aid, pages.Data[0].Cid)
+		signed, err := login.New(tc.Client).SignAndGenerateURL(rawURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		captureFixture(t, "player_v2.json", signed)
+	}
+
+	var resp WebPlayerInfoResponse
+	decodeFixture(t, "player_v2.json", &resp)
+
+	assert.Equal(t, 0, resp.Code)
+	d := resp.Data
+	assert.Equal(t, aid, d.AID)
+	assert.Equal(t, bvid, d.BVID)
+	assert.NotZero(t, d.CID)
+	for _, c := range d.OperationCard {
+		assert.LessOrEqual(t, c.From, c.To)
+	}
+}
+
+func TestVideoDetailFixture(t *testing.T) {
+	if *update {
+		captureFixture(t, "view_detail.json", fmt.Sprintf("https://api.bilibili.com/x/web-interface/view/detail?aid=%d", aid))
+	}
+
+	var resp VideoDetailResponse
+	decodeFixture(t, "view_detail.json", &resp)
+
+	assert.Equal(t, 0, resp.Code)
+	d := resp.Data
+	assert.Equal(t, aid, d.View.AID)
+	assert.Equal(t, bvid, d.View.BVID)
+	if assert.NotEmpty(t, d.View.Pages) {
+		assert.Equal(t, d.View.CID, d.View.Pages[0].CID)
+	}
+	assert.Equal(t, strconv.Itoa(d.View.Owner.MID), d.Card.Card.Mid)
+	assert.NotEmpty(t, d.Tags)
+	for _, r := range d.Reply.Replies {
+		assert.Equal(t, aid, r.Oid)
+	}
+
+	untyped := map[string]json.RawMessage{
+		"view.premiere": d.View.Premiere,
+		"spec":          d.Spec,
+		"elec":          d.Elec,
+		"recommend":     d.Recommend,
+		"guide":         d.Guide,
+		"query_tags":    d.QueryTags,
+		"participle":    d.Participle,
+		"module_ctrl":   d.ModuleCtrl,
+	}
+	for i, item := range d.HotShare.List {
+		untyped[fmt.Sprintf("hot_share.list[%d]", i)] = item
+	}
+	for i, r := range d.Related {
+		untyped[fmt.Sprintf("related[%d].ogv_info", i)] = r.OgvInfo
+	}
+	assertUntyped(t, untyped)
+}
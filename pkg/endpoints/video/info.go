@@ -1,6 +1,7 @@
 package video
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -130,43 +131,53 @@ type VideoInfoResponse struct {
 }
 
 type VideoData struct {
-	BVID               string       `json:"bvid"`                 // 稿件bvid
-	AID                int          `json:"aid"`                  // 稿件avid
-	Videos             int          `json:"videos"`               // 稿件分P总数, 默认为1
-	TID                int          `json:"tid"`                  // 分区tid
-	TName              string       `json:"tname"`                // 子分区名称
-	Copyright          int          `json:"copyright"`            // 视频类型: 1表示原创, 2表示转载
-	Pic                string       `json:"pic"`                  // 稿件封面图片url
-	Title              string       `json:"title"`                // 稿件标题
-	PubDate            int          `json:"pubdate"`              // 稿件发布时间, 秒级时间戳
-	CTime              int          `json:"ctime"`                // 用户投稿时间, 秒级时间戳
-	Desc               string       `json:"desc"`                 // 视频简介
-	DescV2             []DescV2Item `json:"desc_v2"`              // 新版视频简介
-	State              int          `json:"state"`                // 视频状态
-	Duration           int          `json:"duration"`             // 稿件总时长(所有分P), 单位为秒
-	Forward            int          `json:"forward"`              // 撞车视频跳转avid
-	MissionID          int          `json:"mission_id"`           // 稿件参与的活动id
-	RedirectURL        string       `json:"redirect_url"`         // 重定向url，仅番剧或影视视频存在此字段
-	Rights             Rights       `json:"rights"`               // 视频属性标志
-	Owner              Owner        `json:"owner"`                // 视频UP主信息
-	Stat               Stat         `json:"stat"`                 // 视频状态数
-	Dynamic            string       `json:"dynamic"`              // 视频同步发布的动态的文字内容
-	CID                int          `json:"cid"`                  // 视频1P cid
-	Dimension          Dimension    `json:"dimension"`            // 视频1P分辨率
-	Premiere           interface{}  `json:"premiere"`             // null
-	TeenageMode        int          `json:"teenage_mode"`         // 未成年人模式
-	IsChargeableSeason bool         `json:"is_chargeable_season"` // 是否为付费季
-	IsStory            bool         `json:"is_story"`             // 是否为故事片
-	NoCache            bool         `json:"no_cache"`             // 作用尚不明确
-	Pages              []Page       `json:"pages"`                // 视频分P列表
-	Subtitle           Subtitle     `json:"subtitle"`             // 视频CC字幕信息
-	UgcSeason          UGCSeason    `json:"ugc_season"`           // 合集信息
-	Staff              []Staff      `json:"staff"`                // 合作成员列表
-	IsSeasonDisplay    bool         `json:"is_season_display"`    // 是否为季显示
-	UserGarb           interface{}  `json:"user_garb"`            // 用户装扮信息
-	HonorReply         HonorReply   `json:"honor_reply"`          // 荣誉回复信息
-	LikeIcon           string       `json:"like_icon"`            // 点赞图标
-	ArgueInfo          ArgueInfo    `json:"argue_info"`           // 争议/警告信息
+	BVID               string          `json:"bvid"`                 // 稿件bvid
+	AID                int             `json:"aid"`                  // 稿件avid
+	Videos             int             `json:"videos"`               // 稿件分P总数, 默认为1
+	TID                int             `json:"tid"`                  // 分区tid
+	TName              string          `json:"tname"`                // 子分区名称
+	Copyright          int             `json:"copyright"`            // 视频类型: 1表示原创, 2表示转载
+	Pic                string          `json:"pic"`                  // 稿件封面图片url
+	Title              string          `json:"title"`                // 稿件标题
+	PubDate            int             `json:"pubdate"`              // 稿件发布时间, 秒级时间戳
+	CTime              int             `json:"ctime"`                // 用户投稿时间, 秒级时间戳
+	Desc               string          `json:"desc"`                 // 视频简介
+	DescV2             []DescV2Item    `json:"desc_v2"`              // 新版视频简介
+	State              int             `json:"state"`                // 视频状态
+	Duration           int             `json:"duration"`             // 稿件总时长(所有分P), 单位为秒
+	Forward            int             `json:"forward"`              // 撞车视频跳转avid
+	MissionID          int             `json:"mission_id"`           // 稿件参与的活动id
+	RedirectURL        string          `json:"redirect_url"`         // 重定向url，仅番剧或影视视频存在此字段
+	Rights             Rights          `json:"rights"`               // 视频属性标志
+	Owner              Owner           `json:"owner"`                // 视频UP主信息
+	Stat               Stat            `json:"stat"`                 // 视频状态数
+	Dynamic            string          `json:"dynamic"`              // 视频同步发布的动态的文字内容
+	CID                int             `json:"cid"`                  // 视频1P cid
+	Dimension          Dimension       `json:"dimension"`            // 视频1P分辨率
+	Premiere           json.RawMessage `json:"premiere"`             // 首映信息, 结构尚未确认, 非首映稿件为 null
+	TeenageMode        int             `json:"teenage_mode"`         // 未成年人模式
+	IsChargeableSeason bool            `json:"is_chargeable_season"` // 是否为付费季
+	IsStory            bool            `json:"is_story"`             // 是否为故事片
+	NoCache            bool            `json:"no_cache"`             // 作用尚不明确
+	Pages              []Page          `json:"pages"`                // 视频分P列表
+	Subtitle           Subtitle        `json:"subtitle"`             // 视频CC字幕信息
+	UgcSeason          UGCSeason       `json:"ugc_season"`           // 合集信息
+	Staff              []Staff         `json:"staff"`                // 合作成员列表
+	IsSeasonDisplay    bool            `json:"is_season_display"`    // 是否为季显示
+	UserGarb           UserGarb        `json:"user_garb"`            // 用户装扮信息
+	HonorReply         HonorReply      `json:"honor_reply"`          // 荣誉回复信息
+	LikeIcon           string          `json:"like_icon"`            // 点赞图标
+	ArgueInfo          ArgueInfo       `json:"argue_info"`           // 争议/警告信息
+	SeasonID           int             `json:"season_id"`            // 所属合集 id, 仅合集视频存在
+	EnableVT           int             `json:"enable_vt"`            // 作用尚不明确
+	VTDisplay          string          `json:"vt_display"`           // 作用尚不明确
+	IsUpowerExclusive  bool            `json:"is_upower_exclusive"`  // 是否为充电专属视频
+	IsUpowerPlay       bool            `json:"is_upower_play"`       // 是否可以观看充电专属视频
+	IsUpowerPreview    bool            `json:"is_upower_preview"`    // 是否为充电专属试看
+	NeedJumpBV         bool            `json:"need_jump_bv"`         // 作用尚不明确
+	DisableShowUpInfo  bool            `json:"disable_show_up_info"` // 是否隐藏 UP 主信息
+	IsStoryPlay        int             `json:"is_story_play"`        // 作用尚不明确
+	IsViewSelf         bool            `json:"is_view_self"`         // 是否为 UP 主本人观看
 }
 
 // DescV2Item represents the structure of a description item in the desc_v2 array.
@@ -235,6 +246,7 @@ type Page struct {
 	Weblink    string    `json:"weblink"`     // 站外视频跳转url，仅站外视频有效
 	Dimension  Dimension `json:"dimension"`   // 当前分P分辨率
 	FirstFrame string    `json:"first_frame"` // 封面图
+	CTime      int       `json:"ctime"`       // 分P上传时间, 秒级时间戳
 }
 
 // Dimension represents the dimension of a video page.
@@ -258,20 +270,27 @@ type SubtitleItem struct {
 	IsLock      bool           `json:"is_lock"`      // 是否锁定
 	AuthorMID   int            `json:"author_mid"`   // 字幕上传者mid
 	SubtitleURL string         `json:"subtitle_url"` // json格式字幕文件url
+	Type        int            `json:"type"`         // 字幕类型, 0: 人工, 1: AI
+	IDStr       string         `json:"id_str"`       // 字符串形式的字幕id
+	AIType      int            `json:"ai_type"`      // AI 类型, 0: 非 AI 字幕
+	AIStatus    int            `json:"ai_status"`    // AI 状态, 0: 非 AI 字幕, 2: 已生成
 	Author      SubtitleAuthor `json:"author"`       // 字幕上传者信息
 }
 
 // SubtitleAuthor represents the author of the subtitle.
 type SubtitleAuthor struct {
-	MID           int    `json:"mid"`             // 字幕上传者mid
-	Name          string `json:"name"`            // 字幕上传者昵称
-	Sex           string `json:"sex"`             // 字幕上传者性别: 男/女/保密
-	Face          string `json:"face"`            // 字幕上传者头像url
-	Sign          string `json:"sign"`            // 字幕上传者签名
-	Rank          int    `json:"rank"`            // 作用尚不明确
-	Birthday      int    `json:"birthday"`        // 生日，作用尚不明确
-	IsFakeAccount int    `json:"is_fake_account"` // 是否假账号
-	IsDeleted     int    `json:"is_deleted"`      // 是否已删除
+	MID            int         `json:"mid"`              // 字幕上传者mid
+	Name           string      `json:"name"`             // 字幕上传者昵称
+	Sex            string      `json:"sex"`              // 字幕上传者性别: 男/女/保密
+	Face           string      `json:"face"`             // 字幕上传者头像url
+	Sign           string      `json:"sign"`             // 字幕上传者签名
+	Rank           int         `json:"rank"`             // 作用尚不明确
+	Birthday       int         `json:"birthday"`         // 生日，作用尚不明确
+	IsFakeAccount  int         `json:"is_fake_account"`  // 是否假账号
+	IsDeleted      int         `json:"is_deleted"`       // 是否已删除
+	InRegAudit     int         `json:"in_reg_audit"`     // 是否在注册审核中
+	IsSeniorMember int         `json:"is_senior_member"` // 是否为硬核会员
+	NameRender     *NameRender `json:"name_render"`      // 昵称渲染信息, 无特殊渲染时为 null
 }
 
 // Staff represents a staff member of the video.
@@ -322,14 +341,35 @@ type ArgueInfo struct {
 }
 
 type UGCSeason struct {
-	ID        int       `json:"id"`         // 作品的唯一标识符
-	Title     string    `json:"title"`      // 作品的标题
-	Cover     string    `json:"cover"`      // 作品封面图片的URL
-	MID       int       `json:"mid"`        // 作品作者的唯一标识符
-	Intro     string    `json:"intro"`      // 作品的简介
-	SignState int       `json:"sign_state"` // 作品的签约状态
-	Attribute int       `json:"attribute"`  // 作品的属性标识符
-	Sections  []Section `json:"sections"`   // 作品的章节列表
+	ID          int        `json:"id"`            // 作品的唯一标识符
+	Title       string     `json:"title"`         // 作品的标题
+	Cover       string     `json:"cover"`         // 作品封面图片的URL
+	MID         int        `json:"mid"`           // 作品作者的唯一标识符
+	Intro       string     `json:"intro"`         // 作品的简介
+	SignState   int        `json:"sign_state"`    // 作品的签约状态
+	Attribute   int        `json:"attribute"`     // 作品的属性标识符
+	Sections    []Section  `json:"sections"`      // 作品的章节列表
+	Stat        SeasonStat `json:"stat"`          // 合集状态数
+	EpCount     int        `json:"ep_count"`      // 合集视频数
+	SeasonType  int        `json:"season_type"`   // 合集类型
+	IsPaySeason bool       `json:"is_pay_season"` // 是否为付费合集
+	EnableVT    int        `json:"enable_vt"`     // 作用尚不明确
+}
+
+// SeasonStat 合集状态数
+type SeasonStat struct {
+	SeasonID int `json:"season_id"` // 合集 id
+	View     int `json:"view"`      // 播放数
+	Danmaku  int `json:"danmaku"`   // 弹幕数
+	Reply    int `json:"reply"`     // 评论数
+	Fav      int `json:"fav"`       // 收藏数
+	Coin     int `json:"coin"`      // 投币数
+	Share    int `json:"share"`     // 分享数
+	NowRank  int `json:"now_rank"`  // 当前排名
+	HisRank  int `json:"his_rank"`  // 历史最高排行
+	Like     int `json:"like"`      // 获赞数
+	VT       int `json:"vt"`        // 作用尚不明确
+	VV       int `json:"vv"`        // 作用尚不明确
 }
 
 type Section struct {
@@ -354,23 +394,28 @@ type Episode struct {
 }
 
 type Arc struct {
-	AID       int       `json:"aid"`       // 视频的唯一标识符
-	Videos    int       `json:"videos"`    // 视频的数量
-	TypeID    int       `json:"type_id"`   // 视频的类型ID
-	TypeName  string    `json:"type_name"` // 视频的类型名称
-	Copyright int       `json:"copyright"` // 视频的版权类型
-	Pic       string    `json:"pic"`       // 视频封面的URL
-	Title     string    `json:"title"`     // 视频的标题
-	Pubdate   int       `json:"pubdate"`   // 视频的发布时间
-	Ctime     int       `json:"ctime"`     // 视频创建的时间
-	Desc      string    `json:"desc"`      // 视频的描述
-	State     int       `json:"state"`     // 视频的状态
-	Duration  int       `json:"duration"`  // 视频的时长，单位为秒
-	Rights    Rights    `json:"rights"`    // 视频的权限信息
-	Author    Author    `json:"author"`    // 视频的作者信息
-	Stat      Stat      `json:"stat"`      // 视频的统计数据
-	Dynamic   string    `json:"dynamic"`   // 视频的动态内容
-	Dimension Dimension `json:"dimension"` // 视频的维度信息（分辨率等）
+	AID                int          `json:"aid"`                  // 视频的唯一标识符
+	Videos             int          `json:"videos"`               // 视频的数量
+	TypeID             int          `json:"type_id"`              // 视频的类型ID
+	TypeName           string       `json:"type_name"`            // 视频的类型名称
+	Copyright          int          `json:"copyright"`            // 视频的版权类型
+	Pic                string       `json:"pic"`                  // 视频封面的URL
+	Title              string       `json:"title"`                // 视频的标题
+	Pubdate            int          `json:"pubdate"`              // 视频的发布时间
+	Ctime              int          `json:"ctime"`                // 视频创建的时间
+	Desc               string       `json:"desc"`                 // 视频的描述
+	State              int          `json:"state"`                // 视频的状态
+	Duration           int          `json:"duration"`             // 视频的时长，单位为秒
+	Rights             Rights       `json:"rights"`               // 视频的权限信息
+	Author             Author       `json:"author"`               // 视频的作者信息
+	Stat               Stat         `json:"stat"`                 // 视频的统计数据
+	Dynamic            string       `json:"dynamic"`              // 视频的动态内容
+	Dimension          Dimension    `json:"dimension"`            // 视频的维度信息（分辨率等）
+	DescV2             []DescV2Item `json:"desc_v2"`              // 新版视频简介
+	IsChargeableSeason bool         `json:"is_chargeable_season"` // 是否为付费季
+	IsBlooper          bool         `json:"is_blooper"`           // 作用尚不明确
+	EnableVT           int          `json:"enable_vt"`            // 作用尚不明确
+	VTDisplay          string       `json:"vt_display"`           // 作用尚不明确
 }
 
 type Author struct {
@@ -384,17 +429,22 @@ type VideoDetailResponse struct {
 	Message string `json:"message"` // 错误信息，默认为0
 	TTL     int    `json:"ttl"`     // 时间戳，默认为1
 	Data    struct {
-		View      VideoData   `json:"view"`       // 视频基本信息
-		Card      Card        `json:"card"`       // 视频 UP 主信息
-		Tags      []Tag       `json:"tags"`       // 视频 TAG 信息
-		Reply     Reply       `json:"reply"`      // 视频热评信息
-		Related   []Related   `json:"related"`    // 推荐视频信息
-		Spec      interface{} `json:"spec"`       // 作用尚不明确
-		HotShare  HotShare    `json:"hot_share"`  // 作用尚不明确
-		Elec      interface{} `json:"elec"`       // 作用尚不明确
-		Recommend interface{} `json:"recommend"`  // 作用尚不明确
-		ViewAddit ViewAddit   `json:"view_addit"` // 作用尚不明确
-
+		View             VideoData       `json:"view"`              // 视频基本信息
+		Card             Card            `json:"card"`              // 视频 UP 主信息
+		Tags             []Tag           `json:"tags"`              // 视频 TAG 信息
+		Reply            Reply           `json:"reply"`             // 视频热评信息
+		Related          []Related       `json:"related"`           // 推荐视频信息
+		Spec             json.RawMessage `json:"spec"`              // 结构尚未确认, 默认为 null
+		HotShare         HotShare        `json:"hot_share"`         // 作用尚不明确
+		Elec             json.RawMessage `json:"elec"`              // 充电信息, 结构尚未确认, 默认为 null
+		Recommend        json.RawMessage `json:"recommend"`         // 结构尚未确认, 默认为 null
+		ViewAddit        ViewAddit       `json:"view_addit"`        // 作用尚不明确
+		Emergency        Emergency       `json:"emergency"`         // 紧急状态下禁用的操作
+		Guide            json.RawMessage `json:"guide"`             // 结构尚未确认, 默认为 null
+		QueryTags        json.RawMessage `json:"query_tags"`        // 结构尚未确认, 默认为 null
+		Participle       json.RawMessage `json:"participle"`        // 标题分词, 结构尚未确认, 默认为 null
+		ModuleCtrl       json.RawMessage `json:"module_ctrl"`       // 结构尚未确认, 默认为 null
+		ReplaceRecommend bool            `json:"replace_recommend"` // 作用尚不明确
 	} `json:"data"` // 信息本体
 }
 
@@ -418,6 +468,7 @@ type CardInfo struct {
 	Rank           string         `json:"rank"`             // 作用尚不明确，默认为 10000
 	Face           string         `json:"face"`             // 用户头像链接
 	FaceNft        int            `json:"face_nft"`         // 是否为 nft 头像，0 不是，1 是
+	FaceNftType    int            `json:"face_nft_type"`    // nft 头像类型
 	DisplayRank    string         `json:"DisplayRank"`      // 作用尚不明确，默认为 0
 	Regtime        int            `json:"regtime"`          // 作用尚不明确，默认为 0
 	Spacesta       int            `json:"spacesta"`         // 作用尚不明确，默认为 0
@@ -425,7 +476,7 @@ type CardInfo struct {
 	Place          string         `json:"place"`            // 作用尚不明确，默认为空
 	Description    string         `json:"description"`      // 作用尚不明确，默认为空
 	Article        int            `json:"article"`          // 作用尚不明确，默认为 0
-	Attentions     []interface{}  `json:"attentions"`       // 作用尚不明确，默认为空
+	Attentions     []int          `json:"attentions"`       // 关注的用户 mid 列表，默认为空
 	Fans           int            `json:"fans"`             // 粉丝数
 	Friend         int            `json:"friend"`           // 关注数
 	Attention      int            `json:"attention"`        // 关注数
@@ -437,6 +488,7 @@ type CardInfo struct {
 	OfficialVerify OfficialVerify `json:"official_verify"`  // 认证信息2
 	Vip            Vip            `json:"vip"`              // 大会员状态
 	IsSeniorMember int            `json:"is_senior_member"` // 是否为硬核会员，0：否，1：是
+	NameRender     *NameRender    `json:"name_render"`      // 昵称渲染信息，无特殊渲染时为 null
 }
 
 // LevelInfo 表示 Card 中的 level_info 对象
@@ -445,14 +497,18 @@ type LevelInfo struct {
 	CurrentMin   int `json:"current_min"`   // 作用尚不明确，默认为 0
 	CurrentExp   int `json:"current_exp"`   // 作用尚不明确，默认为 0
 	NextExp      int `json:"next_exp"`      // 作用尚不明确，默认为 0
+	LevelUp      int `json:"level_up"`      // 升级时间戳, 仅播放器信息中存在
 }
 
 // Pendant 表示 Card 中的 pendant 对象
 type Pendant struct {
-	Pid    int    `json:"pid"`    // 挂件 id
-	Name   string `json:"name"`   // 挂件名称
-	Image  string `json:"image"`  // 挂件图片 url
-	Expire int    `json:"expire"` // 作用尚不明确，默认为 0
+	Pid               int    `json:"pid"`                 // 挂件 id
+	Name              string `json:"name"`                // 挂件名称
+	Image             string `json:"image"`               // 挂件图片 url
+	Expire            int    `json:"expire"`              // 作用尚不明确，默认为 0
+	ImageEnhance      string `json:"image_enhance"`       // 挂件动态图片 url
+	ImageEnhanceFrame string `json:"image_enhance_frame"` // 挂件动态图片帧 url
+	NPid              int    `json:"n_pid"`               // 新版挂件 id
 }
 
 // Nameplate 表示 Card 中的 nameplate 对象
@@ -481,18 +537,65 @@ type OfficialVerify struct {
 
 // Vip 表示 Card 中的 vip 对象
 type Vip struct {
-	Type               int    `json:"type"`                 // 会员类型，0：无，1：月大会员，2：年度及以上大会员
-	Status             int    `json:"status"`               // 会员状态，0：无，1：有
-	DueDate            int    `json:"due_date"`             // 会员过期时间，Unix 时间戳（毫秒）
-	VipPayType         int    `json:"vip_pay_type"`         // 支付类型，0：未支付，1：已支付
-	ThemeType          int    `json:"theme_type"`           // 作用尚不明确，默认为 0
-	Label              Label  `json:"label"`                // 会员标签
-	AvatarSubscript    int    `json:"avatar_subscript"`     // 是否显示会员图标，0：不显示，1：显示
-	NicknameColor      string `json:"nickname_color"`       // 会员昵称颜色，颜色码
-	Role               int    `json:"role"`                 // 大角色类型，1：月度大会员，3：年度大会员，7：十年大会员，15：百年大会员
-	AvatarSubscriptURL string `json:"avatar_subscript_url"` // 大会员角标地址
-	TVVipStatus        int    `json:"tv_vip_status"`        // 电视大会员状态，0：未开通
-	TVVipPayType       int    `json:"tv_vip_pay_type"`      // 电视大会员支付类型
+	Type               int         `json:"type"`                 // 会员类型，0：无，1：月大会员，2：年度及以上大会员
+	Status             int         `json:"status"`               // 会员状态，0：无，1：有
+	DueDate            int         `json:"due_date"`             // 会员过期时间，Unix 时间戳（毫秒）
+	VipPayType         int         `json:"vip_pay_type"`         // 支付类型，0：未支付，1：已支付
+	ThemeType          int         `json:"theme_type"`           // 作用尚不明确，默认为 0
+	Label              Label       `json:"label"`                // 会员标签
+	AvatarSubscript    int         `json:"avatar_subscript"`     // 是否显示会员图标，0：不显示，1：显示
+	NicknameColor      string      `json:"nickname_color"`       // 会员昵称颜色，颜色码
+	Role               int         `json:"role"`                 // 大角色类型，1：月度大会员，3：年度大会员，7：十年大会员，15：百年大会员
+	AvatarSubscriptURL string      `json:"avatar_subscript_url"` // 大会员角标地址
+	TVVipStatus        int         `json:"tv_vip_status"`        // 电视大会员状态，0：未开通
+	TVVipPayType       int         `json:"tv_vip_pay_type"`      // 电视大会员支付类型
+	TVDueDate          int         `json:"tv_due_date"`          // 电视大会员过期时间
+	AvatarIcon         *AvatarIcon `json:"avatar_icon"`          // 头像角标
+	VipType            int         `json:"vipType"`              // 同 type, 仅 Card 中存在
+	VipStatus          int         `json:"vipStatus"`            // 同 status, 仅 Card 中存在
+}
+
+// AvatarIcon 表示 Vip 中的 avatar_icon 对象
+type AvatarIcon struct {
+	IconType     int          `json:"icon_type"`     // 角标类型
+	IconResource IconResource `json:"icon_resource"` // 角标资源, 无角标时为空对象
+}
+
+// IconResource 表示 AvatarIcon 中的 icon_resource 对象
+type IconResource struct {
+	Type int    `json:"type,omitempty"` // 资源类型
+	URL  string `json:"url,omitempty"`  // 角标图片 url
+}
+
+// UserGarb 表示 VideoData 中的 user_garb 对象
+type UserGarb struct {
+	URLImageAniCut string `json:"url_image_ani_cut"` // 装扮动画切图 url, 默认为空
+}
+
+// NameRender 表示昵称渲染信息
+type NameRender struct {
+	ColorsInfo   NameRenderColors `json:"colors_info"`   // 昵称颜色
+	RenderScheme string           `json:"render_scheme"` // 渲染方案, Default：默认，Colorful：彩色
+}
+
+// NameRenderColors 表示 NameRender 中的 colors_info 对象
+type NameRenderColors struct {
+	Color    []NameRenderColor `json:"color"`     // 颜色列表
+	ColorIDs []string          `json:"color_ids"` // 颜色 id 列表
+}
+
+// NameRenderColor 表示昵称的一种颜色
+type NameRenderColor struct {
+	ColorDay   string `json:"color_day"`   // 日间模式颜色
+	ColorNight string `json:"color_night"` // 夜间模式颜色
+}
+
+// Emergency 表示紧急状态下禁用的操作
+type Emergency struct {
+	NoLike  bool `json:"no_like"`  // 禁止点赞
+	NoCoin  bool `json:"no_coin"`  // 禁止投币
+	NoFav   bool `json:"no_fav"`   // 禁止收藏
+	NoShare bool `json:"no_share"` // 禁止分享
 }
 
 // Label 表示 Vip 中的 label 对象
@@ -517,55 +620,95 @@ type Space struct {
 	LImg string `json:"l_img"` // 主页头图 url 正常
 }
 
-// TODO 视频 TAG 信息
-type Tag struct {
-	// 根据具体字段定义
-}
-
-// TODO Reply 表示视频热评信息
+// Reply 表示视频热评信息
 type Reply struct {
-	// 根据具体字段定义
-}
-
-// TODO Reply 表示视频热评信息
+	Page    *ReplyPage `json:"page"`    // 分页信息，默认为 null
+	Replies []HotReply `json:"replies"` // 热评列表
+}
+
+// ReplyPage 表示 Reply 中的 page 对象
+type ReplyPage struct {
+	Acount int `json:"acount"` // 总评论数(含回复)
+	Count  int `json:"count"`  // 一级评论数
+	Num    int `json:"num"`    // 当前页码
+	Size   int `json:"size"`   // 每页条数
+}
+
+// HotReply 表示视频详情中的热评
+type HotReply struct {
+	Rpid       int              `json:"rpid"`        // 评论 rpid
+	Oid        int              `json:"oid"`         // 稿件 avid
+	Type       int              `json:"type"`        // 评论区类型，1：视频
+	Mid        int              `json:"mid"`         // 发送者 mid
+	Root       int              `json:"root"`        // 根评论 rpid
+	Parent     int              `json:"parent"`      // 回复父评论 rpid
+	Dialog     int              `json:"dialog"`      // 回复对方 rpid
+	Count      int              `json:"count"`       // 二级评论条数
+	Rcount     int              `json:"rcount"`      // 回复评论条数
+	State      int              `json:"state"`       // 评论状态
+	Fansgrade  int              `json:"fansgrade"`   // 是否具有粉丝标签
+	Attr       int              `json:"attr"`        // 评论属性
+	Ctime      int              `json:"ctime"`       // 评论发送时间
+	Like       int              `json:"like"`        // 评论获赞数
+	Action     int              `json:"action"`      // 当前用户操作状态，0：无，1：已点赞，2：已点踩
+	Content    *HotReplyContent `json:"content"`     // 评论内容，此处通常为 null
+	Replies    []HotReply       `json:"replies"`     // 评论回复，此处通常为 null
+	Assist     int              `json:"assist"`      // 作用尚不明确
+	ShowFollow bool             `json:"show_follow"` // 作用尚不明确
+}
+
+// HotReplyContent 表示 HotReply 中的 content 对象
+type HotReplyContent struct {
+	Message string `json:"message"` // 评论正文
+}
+
+// Related 表示推荐视频信息
 type Related struct {
-	Aid         int         `json:"aid"`           // 视频AV号
-	Videos      int         `json:"videos"`        // 视频集数
-	Tid         int         `json:"tid"`           // 分区ID
-	Tname       string      `json:"tname"`         // 分区名称
-	Copyright   int         `json:"copyright"`     // 版权标识
-	Pic         string      `json:"pic"`           // 视频封面图片地址
-	Title       string      `json:"title"`         // 视频标题
-	Pubdate     int         `json:"pubdate"`       // 发布时间，Unix时间戳
-	Ctime       int         `json:"ctime"`         // 创建时间，Unix时间戳
-	Desc        string      `json:"desc"`          // 视频描述
-	State       int         `json:"state"`         // 状态
-	Duration    int         `json:"duration"`      // 视频时长，单位秒
-	MissionID   int         `json:"mission_id"`    // 任务ID
-	Rights      Rights      `json:"rights"`        // 视频权限信息
-	Owner       Owner       `json:"owner"`         // 视频UP主信息
-	Stat        Stat        `json:"stat"`          // 视频统计信息
-	Dynamic     string      `json:"dynamic"`       // 动态信息
-	Cid         int         `json:"cid"`           // 视频CID
-	Dimension   Dimension   `json:"dimension"`     // 视频维度信息
-	SeasonID    int         `json:"season_id"`     // 剧集ID
-	ShortLinkV2 string      `json:"short_link_v2"` // 短链接
-	FirstFrame  string      `json:"first_frame"`   // 视频第一帧图片地址
-	PubLocation string      `json:"pub_location"`  // 发布位置
-	Cover43     string      `json:"cover43"`       // 4:3比例封面图片地址
-	Bvid        string      `json:"bvid"`          // 视频BV号
-	SeasonType  int         `json:"season_type"`   // 剧集类型
-	IsOgv       bool        `json:"is_ogv"`        // 是否为OGV内容
-	OgvInfo     interface{} `json:"ogv_info"`      // OGV信息，可能为空
-	RcmdReason  string      `json:"rcmd_reason"`   // 推荐原因
-	EnableVt    int         `json:"enable_vt"`     // 是否启用VT
-	AiRcmd      interface{} `json:"ai_rcmd"`       // AI推荐信息，可能为空
+	Aid         int             `json:"aid"`           // 视频AV号
+	Videos      int             `json:"videos"`        // 视频集数
+	Tid         int             `json:"tid"`           // 分区ID
+	Tname       string          `json:"tname"`         // 分区名称
+	Copyright   int             `json:"copyright"`     // 版权标识
+	Pic         string          `json:"pic"`           // 视频封面图片地址
+	Title       string          `json:"title"`         // 视频标题
+	Pubdate     int             `json:"pubdate"`       // 发布时间，Unix时间戳
+	Ctime       int             `json:"ctime"`         // 创建时间，Unix时间戳
+	Desc        string          `json:"desc"`          // 视频描述
+	State       int             `json:"state"`         // 状态
+	Duration    int             `json:"duration"`      // 视频时长，单位秒
+	MissionID   int             `json:"mission_id"`    // 任务ID
+	Rights      Rights          `json:"rights"`        // 视频权限信息
+	Owner       Owner           `json:"owner"`         // 视频UP主信息
+	Stat        Stat            `json:"stat"`          // 视频统计信息
+	Dynamic     string          `json:"dynamic"`       // 动态信息
+	Cid         int             `json:"cid"`           // 视频CID
+	Dimension   Dimension       `json:"dimension"`     // 视频维度信息
+	SeasonID    int             `json:"season_id"`     // 剧集ID
+	ShortLinkV2 string          `json:"short_link_v2"` // 短链接
+	FirstFrame  string          `json:"first_frame"`   // 视频第一帧图片地址
+	PubLocation string          `json:"pub_location"`  // 发布位置
+	Cover43     string          `json:"cover43"`       // 4:3比例封面图片地址
+	Bvid        string          `json:"bvid"`          // 视频BV号
+	SeasonType  int             `json:"season_type"`   // 剧集类型
+	IsOgv       bool            `json:"is_ogv"`        // 是否为OGV内容
+	OgvInfo     json.RawMessage `json:"ogv_info"`      // OGV信息，结构尚未确认，非OGV内容为 null
+	RcmdReason  string          `json:"rcmd_reason"`   // 推荐原因
+	EnableVt    int             `json:"enable_vt"`     // 是否启用VT
+	AiRcmd      *AiRcmd         `json:"ai_rcmd"`       // AI推荐信息，可能为空
+}
+
+// AiRcmd 表示推荐视频的 ai_rcmd 对象
+type AiRcmd struct {
+	ID      int    `json:"id"`      // 推荐稿件 avid
+	Goto    string `json:"goto"`    // 跳转类型，av：视频
+	Trackid string `json:"trackid"` // 推荐追踪 id
+	UniqID  string `json:"uniq_id"` // 作用尚不明确，默认为空
 }
 
 // HotShare 表示 hot_share 对象
 type HotShare struct {
-	Show bool          `json:"show"` // 作用尚不明确，默认为 false
-	List []interface{} `json:"list"` // 作用尚不明确，默认为空
+	Show bool              `json:"show"` // 作用尚不明确，默认为 false
+	List []json.RawMessage `json:"list"` // 结构尚未确认，默认为空
 }
 
 // ViewAddit 表示 view_addit 对象
@@ -49,3 +49,18 @@ func TestPageList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, resp.Code, 0)
 }
+
+func TestPlayerInfo(t *testing.T) {
+	tc := tests.NewTestClient().WithSessdata()
+	service := New(tc.Client)
+
+	pages, err := service.PageList(aid, "")
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, pages.Data) {
+		resp, err := service.PlayerInfo(aid, "", pages.Data[0].Cid)
+		t.Logf("Response: %+v", resp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, resp.Code)
+	}
+}
@@ -1,3 +1,352 @@
 package video
 
-// 互动视频? 很少用
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 获取互动视频剧情图版本
+//
+// Parameters:
+//   - aid (int): 视频的 aid (可选)
+//   - bvid (string): 视频的 bvid (可选)
+//   - cid (int): 视频 1P 的 cid (必要)
+//
+// Authentication:
+//   - 鉴权方式：Wbi 签名
+//
+// 备注：
+//   - 非互动视频返回错误, 可先通过 Rights.IsSteinGate 判断
+func (v *Video) GraphVersion(aid int, bvid string, cid int) (int, error) {
+	resp, err := v.PlayerInfo(aid, bvid, cid)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Code != 0 {
+		return 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+	}
+	if resp.Data.Interaction == nil || resp.Data.Interaction.GraphVersion == 0 {
+		return 0, fmt.Errorf("video is not an interactive video")
+	}
+	return resp.Data.Interaction.GraphVersion, nil
+}
+
+// 获取互动视频模块详细信息
+//
+// Parameters:
+//   - aid (int): 视频的 aid, 与 bvid 任选一个
+//   - bvid (string): 视频的 bvid, 与 aid 任选一个
+//   - graphVersion (int): 剧情图版本, 见 GraphVersion
+//   - edgeID (int): 模块编号, 0 或留空为起始模块
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+func (v *Video) EdgeInfo(aid int, bvid string, graphVersion int, edgeID int) (*EdgeInfoResponse, error) {
+	baseURL := "https://api.bilibili.com/x/stein/edgeinfo_v2"
+
+	formData := map[string]string{
+		"aid":           fmt.Sprintf("%d", aid),
+		"bvid":          bvid,
+		"graph_version": fmt.Sprintf("%d", graphVersion),
+	}
+	if edgeID != 0 {
+		formData["edge_id"] = fmt.Sprintf("%d", edgeID)
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&EdgeInfoResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*EdgeInfoResponse), nil
+}
+
+// 遍历互动视频的全部模块与选项, 生成剧情图
+//
+// Parameters:
+//   - aid (int): 视频的 aid, 与 bvid 任选一个
+//   - bvid (string): 视频的 bvid, 与 aid 任选一个
+//   - cid (int): 视频 1P 的 cid
+//   - maxNodes (int): 最多遍历的模块数, 0 表示不限制
+//   - interval (time.Duration): 两次请求的间隔, 避免触发风控
+func (v *Video) WalkSteinGraph(aid int, bvid string, cid int, maxNodes int, interval time.Duration) (*SteinGraph, error) {
+	version, err := v.GraphVersion(aid, bvid, cid)
+	if err != nil {
+		return nil, err
+	}
+
+	first := true
+	graph, err := walkStein(maxNodes, func(edgeID int) (*EdgeInfoData, error) {
+		if !first && interval > 0 {
+			time.Sleep(interval)
+		}
+		first = false
+
+		resp, err := v.EdgeInfo(aid, bvid, version, edgeID)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, fmt.Errorf("get edge %d: %w", edgeID, &misc.CodeError{Code: resp.Code, Message: resp.Message})
+		}
+		return &resp.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	graph.Aid = aid
+	graph.Bvid = bvid
+	graph.GraphVersion = version
+	return graph, nil
+}
+
+// 广度优先遍历剧情图, fetch 按模块编号获取模块信息, 0 为起始模块
+func walkStein(maxNodes int, fetch func(edgeID int) (*EdgeInfoData, error)) (*SteinGraph, error) {
+	graph := &SteinGraph{}
+	visited := map[int]bool{}
+	queued := map[int]bool{}
+	variables := map[string]SteinVariable{}
+	queue := []int{0}
+
+	for len(queue) > 0 {
+		if maxNodes > 0 && len(graph.Nodes) >= maxNodes {
+			graph.Truncated = true
+			break
+		}
+		edgeID := queue[0]
+		queue = queue[1:]
+
+		data, err := fetch(edgeID)
+		if err != nil {
+			return graph, err
+		}
+		if visited[data.EdgeID] {
+			continue
+		}
+		visited[data.EdgeID] = true
+		if edgeID == 0 {
+			graph.Title = data.Title
+			graph.Root = data.EdgeID
+		}
+
+		node := SteinNode{
+			EdgeID: data.EdgeID,
+			Title:  data.Title,
+			IsLeaf: data.IsLeaf == 1,
+		}
+		for _, s := range data.StoryList {
+			if s.EdgeID == data.EdgeID {
+				node.Cid = s.Cid
+				node.Title = s.Title
+				break
+			}
+		}
+		graph.Nodes = append(graph.Nodes, node)
+
+		for _, v := range data.HiddenVars {
+			variables[v.IDV2] = SteinVariable{ID: v.IDV2, Name: v.Name, Value: v.Value, IsShow: v.IsShow == 1}
+		}
+
+		for _, q := range data.Edges.Questions {
+			for _, c := range q.Choices {
+				graph.Edges = append(graph.Edges, SteinEdge{
+					From:      data.EdgeID,
+					To:        c.ID,
+					Cid:       c.Cid,
+					Option:    c.Option,
+					Condition: c.Condition,
+					Action:    c.NativeAction,
+					IsDefault: c.IsDefault == 1,
+				})
+				if !visited[c.ID] && !queued[c.ID] {
+					queued[c.ID] = true
+					queue = append(queue, c.ID)
+				}
+			}
+		}
+	}
+
+	for _, v := range variables {
+		graph.Variables = append(graph.Variables, v)
+	}
+	sort.Slice(graph.Variables, func(i, j int) bool { return graph.Variables[i].ID < graph.Variables[j].ID })
+	return graph, nil
+}
+
+// SteinGraph 互动视频剧情图
+type SteinGraph struct {
+	Aid          int             `json:"aid"`           // 稿件 avid
+	Bvid         string          `json:"bvid"`          // 稿件 bvid
+	GraphVersion int             `json:"graph_version"` // 剧情图版本
+	Title        string          `json:"title"`         // 起始模块标题
+	Root         int             `json:"root"`          // 起始模块编号
+	Truncated    bool            `json:"truncated"`     // 是否因 maxNodes 提前结束
+	Nodes        []SteinNode     `json:"nodes"`         // 模块列表
+	Edges        []SteinEdge     `json:"edges"`         // 选项列表
+	Variables    []SteinVariable `json:"variables"`     // 隐藏变量
+}
+
+// SteinNode 剧情图中的模块
+type SteinNode struct {
+	EdgeID int    `json:"edge_id"` // 模块编号
+	Cid    int    `json:"cid"`     // 模块对应视频 cid
+	Title  string `json:"title"`   // 模块标题
+	IsLeaf bool   `json:"is_leaf"` // 是否为结局
+}
+
+// SteinEdge 剧情图中的选项
+type SteinEdge struct {
+	From      int    `json:"from"`      // 所在模块编号
+	To        int    `json:"to"`        // 跳转模块编号
+	Cid       int    `json:"cid"`       // 跳转模块 cid
+	Option    string `json:"option"`    // 选项文字
+	Condition string `json:"condition"` // 出现条件, 如 $a>=1
+	Action    string `json:"action"`    // 选择后的变量运算, 如 $a=$a+1
+	IsDefault bool   `json:"is_default"`
+}
+
+// SteinVariable 剧情图中的隐藏变量
+type SteinVariable struct {
+	ID     string  `json:"id"`      // 变量编号, 如 $a
+	Name   string  `json:"name"`    // 变量名
+	Value  float64 `json:"value"`   // 初始值
+	IsShow bool    `json:"is_show"` // 是否在播放器显示
+}
+
+// JSON 导出为 json
+func (g *SteinGraph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// DOT 导出为 Graphviz DOT
+func (g *SteinGraph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph stein {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	if g.Title != "" {
+		fmt.Fprintf(&sb, "  label=%s;\n", dotQuote(g.Title))
+	}
+	for _, n := range g.Nodes {
+		attrs := fmt.Sprintf("label=%s", dotQuote(fmt.Sprintf("%s\ncid=%d", n.Title, n.Cid)))
+		if n.IsLeaf {
+			attrs += ", peripheries=2"
+		}
+		if n.EdgeID == g.Root {
+			attrs += ", style=bold"
+		}
+		fmt.Fprintf(&sb, "  e%d [%s];\n", n.EdgeID, attrs)
+	}
+	for _, e := range g.Edges {
+		label := e.Option
+		if e.Condition != "" {
+			label += "\n[" + e.Condition + "]"
+		}
+		if e.Action != "" {
+			label += "\n{" + e.Action + "}"
+		}
+		attrs := fmt.Sprintf("label=%s", dotQuote(label))
+		if e.IsDefault {
+			attrs += ", style=bold"
+		}
+		fmt.Fprintf(&sb, "  e%d -> e%d [%s];\n", e.From, e.To, attrs)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// Interaction 播放器信息中的互动视频信息
+type Interaction struct {
+	HistoryNode struct {
+		NodeID int    `json:"node_id"` // 上次播放的模块编号
+		Title  string `json:"title"`   // 模块标题
+		Cid    int    `json:"cid"`     // 模块 cid
+	} `json:"history_node"` // 上次播放的模块
+	GraphVersion int    `json:"graph_version"` // 剧情图版本
+	Msg          string `json:"msg"`           // 提示信息
+	Mark         int    `json:"mark"`          // 作用尚不明确
+}
+
+// EdgeInfoResponse 互动视频模块详细信息
+type EdgeInfoResponse struct {
+	Code    int          `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -404表示无视频, 99003表示剧情图版本不存在
+	Message string       `json:"message"` // 错误信息, 默认为0
+	TTL     int          `json:"ttl"`     // TTL, 固定值1
+	Data    EdgeInfoData `json:"data"`    // 数据本体
+}
+
+// EdgeInfoData 互动视频模块
+type EdgeInfoData struct {
+	Title     string `json:"title"`   // 当前模块标题
+	EdgeID    int    `json:"edge_id"` // 当前模块编号
+	StoryList []struct {
+		NodeID    int    `json:"node_id"`    // 模块编号
+		EdgeID    int    `json:"edge_id"`    // 模块编号
+		Title     string `json:"title"`      // 模块标题
+		Cid       int    `json:"cid"`        // 模块 cid
+		StartPos  int    `json:"start_pos"`  // 作用尚不明确
+		Cover     string `json:"cover"`      // 模块封面 url
+		IsCurrent int    `json:"is_current"` // 是否为当前模块
+		Cursor    int    `json:"cursor"`     // 当前模块序号
+	} `json:"story_list"` // 已经历的模块列表
+	Edges struct {
+		Dimension Dimension       `json:"dimension"` // 视频分辨率
+		Questions []SteinQuestion `json:"questions"` // 选项问题列表, 结局模块无此项
+		Skin      interface{}     `json:"skin"`      // 选项样式
+	} `json:"edges"` // 选项信息
+	Preload struct {
+		Video []struct {
+			Aid int `json:"aid"` // 稿件 avid
+			Cid int `json:"cid"` // 预加载 cid
+		} `json:"video"`
+	} `json:"preload"` // 预加载的分 P
+	HiddenVars []struct {
+		Value  float64 `json:"value"`   // 变量当前值
+		ID     string  `json:"id"`      // 变量编号
+		IDV2   string  `json:"id_v2"`   // 变量编号, 如 $a
+		Type   int     `json:"type"`    // 变量类型, 1: 普通变量, 2: 随机值
+		IsShow int     `json:"is_show"` // 是否显示
+		Name   string  `json:"name"`    // 变量名
+	} `json:"hidden_vars"` // 隐藏变量
+	IsLeaf int `json:"is_leaf"` // 是否为结局模块, 0: 否, 1: 是
+}
+
+// SteinQuestion 模块结尾的选项问题
+type SteinQuestion struct {
+	ID         int    `json:"id"`           // 问题编号
+	Type       int    `json:"type"`         // 选项类型, 0: 只有一个选项时自动跳转, 1: 普通选项, 2: 坐标定位选项, 3: 坐标定位不显示
+	StartTimeR int    `json:"start_time_r"` // 选项出现时间, 相对于模块结尾, 单位为毫秒
+	Duration   int    `json:"duration"`     // 选项显示时长, -1 为不限时
+	PauseVideo int    `json:"pause_video"`  // 是否暂停视频
+	Title      string `json:"title"`        // 问题标题
+	Choices    []struct {
+		ID             int    `json:"id"`              // 跳转模块编号
+		PlatformAction string `json:"platform_action"` // 跳转动作, 如 JUMP+cid
+		NativeAction   string `json:"native_action"`   // 变量运算
+		Condition      string `json:"condition"`       // 出现条件
+		Cid            int    `json:"cid"`             // 跳转模块 cid
+		Option         string `json:"option"`          // 选项文字
+		IsDefault      int    `json:"is_default"`      // 是否为默认选项
+	} `json:"choices"` // 选项列表
+}
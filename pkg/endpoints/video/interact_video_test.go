@@ -0,0 +1,57 @@
+package video
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkStein(t *testing.T) {
+	// 起始模块 1 -> 2 / 3, 模块 2 -> 3, 模块 3 为结局
+	edges := map[int]string{
+		1: `{"title":"开始","edge_id":1,"story_list":[{"edge_id":1,"cid":100,"title":"开始"}],
+			"edges":{"questions":[{"choices":[
+				{"id":2,"cid":200,"option":"向左","native_action":"$a=$a+1","is_default":1},
+				{"id":3,"cid":300,"option":"向右","condition":"$a>=1"}]}]},
+			"hidden_vars":[{"id_v2":"$a","name":"好感","value":0,"is_show":1}]}`,
+		2: `{"title":"左","edge_id":2,"story_list":[{"edge_id":2,"cid":200,"title":"左"}],
+			"edges":{"questions":[{"choices":[{"id":3,"cid":300,"option":"继续"}]}]}}`,
+		3: `{"title":"结局","edge_id":3,"story_list":[{"edge_id":3,"cid":300,"title":"结局"}],"is_leaf":1}`,
+	}
+	calls := 0
+	fetch := func(edgeID int) (*EdgeInfoData, error) {
+		calls++
+		if edgeID == 0 {
+			edgeID = 1
+		}
+		var data EdgeInfoData
+		err := json.Unmarshal([]byte(edges[edgeID]), &data)
+		return &data, err
+	}
+
+	graph, err := walkStein(0, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, graph.Root)
+	assert.Len(t, graph.Nodes, 3)
+	assert.Len(t, graph.Edges, 3)
+	assert.True(t, graph.Nodes[2].IsLeaf)
+	assert.Equal(t, []SteinVariable{{ID: "$a", Name: "好感", IsShow: true}}, graph.Variables)
+	assert.Equal(t, 3, calls) // 模块 3 只请求一次
+
+	dot := graph.DOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph stein {"))
+	assert.Contains(t, dot, `e1 -> e2 [label="向左\n{$a=$a+1}", style=bold];`)
+	assert.Contains(t, dot, `e1 -> e3 [label="向右\n[$a>=1]"];`)
+	assert.Contains(t, dot, `e3 [label="结局\ncid=300", peripheries=2];`)
+
+	b, err := graph.JSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"is_leaf": true`)
+
+	graph, err = walkStein(2, fetch)
+	assert.NoError(t, err)
+	assert.True(t, graph.Truncated)
+	assert.Len(t, graph.Nodes, 2)
+}
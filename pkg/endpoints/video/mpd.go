@@ -0,0 +1,255 @@
+package video
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MPD 根节点, 仅包含点播所需的字段
+type MPD struct {
+	XMLName                   xml.Name `xml:"MPD"`
+	Xmlns                     string   `xml:"xmlns,attr"`
+	Profiles                  string   `xml:"profiles,attr"`
+	Type                      string   `xml:"type,attr"`
+	MediaPresentationDuration string   `xml:"mediaPresentationDuration,attr"`
+	MinBufferTime             string   `xml:"minBufferTime,attr"`
+	Period                    Period   `xml:"Period"`
+}
+
+// Period MPD 中的单个时间段
+type Period struct {
+	Duration       string          `xml:"duration,attr,omitempty"`
+	AdaptationSets []AdaptationSet `xml:"AdaptationSet"`
+}
+
+// AdaptationSet 同类型(同编码)的一组码流
+type AdaptationSet struct {
+	ID               int              `xml:"id,attr"`
+	ContentType      string           `xml:"contentType,attr"`
+	MimeType         string           `xml:"mimeType,attr"`
+	SegmentAlignment bool             `xml:"segmentAlignment,attr"`
+	StartWithSAP     int              `xml:"startWithSAP,attr,omitempty"`
+	Representations  []Representation `xml:"Representation"`
+}
+
+// Representation 单个码流
+type Representation struct {
+	ID          string          `xml:"id,attr"`
+	Bandwidth   int             `xml:"bandwidth,attr"`
+	Codecs      string          `xml:"codecs,attr"`
+	MimeType    string          `xml:"mimeType,attr,omitempty"`
+	Width       int             `xml:"width,attr,omitempty"`
+	Height      int             `xml:"height,attr,omitempty"`
+	FrameRate   string          `xml:"frameRate,attr,omitempty"`
+	Sar         string          `xml:"sar,attr,omitempty"`
+	BaseURL     string          `xml:"BaseURL"`
+	SegmentBase *MPDSegmentBase `xml:"SegmentBase,omitempty"`
+}
+
+// MPDSegmentBase 单文件码流的索引位置
+type MPDSegmentBase struct {
+	IndexRange     string            `xml:"indexRange,attr"`
+	Initialization MPDInitialization `xml:"Initialization"`
+}
+
+// MPDInitialization 初始化段位置
+type MPDInitialization struct {
+	Range string `xml:"range,attr"`
+}
+
+// StreamURLFunc 用于改写 MPD 中码流的 BaseURL
+//
+// kind 为 "video" 或 "audio", index 为码流在 DashStreams 返回列表中的下标
+type StreamURLFunc func(kind string, index int, s Stream) string
+
+// 将取流结果转换为 MPEG-DASH MPD 文档
+//
+// Parameters:
+//   - data (*StreamData): Stream 返回的数据本体, 需为 DASH 格式
+//   - urlFunc (StreamURLFunc): 改写码流地址, 为 nil 时直接使用 baseUrl
+//
+// 备注：
+//   - 视频与伴音码流均按编码分组到不同的 AdaptationSet, 便于播放器按兼容性选择
+//   - 杜比与无损伴音各自为一个 AdaptationSet
+func GenerateMPD(data *StreamData, urlFunc StreamURLFunc) ([]byte, error) {
+	if data == nil || data.Dash == nil {
+		return nil, fmt.Errorf("stream data has no dash info")
+	}
+
+	videos, audios := DashStreams(data.Dash)
+	if len(videos) == 0 && len(audios) == 0 {
+		return nil, fmt.Errorf("dash info contains no streams")
+	}
+
+	if urlFunc == nil {
+		urlFunc = func(kind string, index int, s Stream) string {
+			return s.URL()
+		}
+	}
+
+	duration := float64(data.Timelength) / 1000
+	if duration <= 0 {
+		duration = float64(data.Dash.Duration)
+	}
+	minBuffer := data.Dash.MinBufferTime
+	if minBuffer <= 0 {
+		minBuffer = data.Dash.MinBuffer_time
+	}
+
+	mpd := MPD{
+		Xmlns:                     "urn:mpeg:dash:schema:mpd:2011",
+		Profiles:                  "urn:mpeg:dash:profile:isoff-on-demand:2011",
+		Type:                      "static",
+		MediaPresentationDuration: formatISODuration(duration),
+		MinBufferTime:             formatISODuration(minBuffer),
+	}
+
+	// 按编码分组视频码流, 保持接口返回顺序
+	var codecOrder []int
+	groups := map[int][]Representation{}
+	for i, s := range videos {
+		if _, ok := groups[s.Codecid]; !ok {
+			codecOrder = append(codecOrder, s.Codecid)
+		}
+		groups[s.Codecid] = append(groups[s.Codecid], representation(s, urlFunc("video", i, s)))
+	}
+
+	setID := 0
+	for _, codecid := range codecOrder {
+		mpd.Period.AdaptationSets = append(mpd.Period.AdaptationSets, AdaptationSet{
+			ID:               setID,
+			ContentType:      "video",
+			MimeType:         "video/mp4",
+			SegmentAlignment: true,
+			StartWithSAP:     1,
+			Representations:  groups[codecid],
+		})
+		setID++
+	}
+
+	// 按编码分组伴音码流, AAC、杜比 (ec-3) 与无损 (fLaC) 各自成组, 避免播放器切换到无法解码的码流
+	var audioOrder []string
+	audioGroups := map[string][]Representation{}
+	for i, s := range audios {
+		if _, ok := audioGroups[s.Codecs]; !ok {
+			audioOrder = append(audioOrder, s.Codecs)
+		}
+		audioGroups[s.Codecs] = append(audioGroups[s.Codecs], representation(s, urlFunc("audio", i, s)))
+	}
+
+	for _, codecs := range audioOrder {
+		reps := audioGroups[codecs]
+		// 码率从高到低, 播放器默认取第一个
+		sort.SliceStable(reps, func(i, j int) bool {
+			return reps[i].Bandwidth > reps[j].Bandwidth
+		})
+		mpd.Period.AdaptationSets = append(mpd.Period.AdaptationSets, AdaptationSet{
+			ID:               setID,
+			ContentType:      "audio",
+			MimeType:         "audio/mp4",
+			SegmentAlignment: true,
+			StartWithSAP:     1,
+			Representations:  reps,
+		})
+		setID++
+	}
+
+	out, err := xml.MarshalIndent(mpd, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// 展开 Dash 中的视频与伴音码流, 伴音包含杜比与无损音轨
+func DashStreams(dash *Dash) (videos []Stream, audios []Stream) {
+	if dash == nil {
+		return nil, nil
+	}
+	videos = append(videos, dash.Video...)
+	audios = append(audios, dash.Audio...)
+	if dash.Dolby != nil {
+		audios = append(audios, dash.Dolby.Audio...)
+	}
+	if dash.Flac != nil && dash.Flac.Audio.URL() != "" {
+		audios = append(audios, dash.Flac.Audio)
+	}
+	return videos, audios
+}
+
+// URL 返回码流地址, 兼容两种字段命名
+func (s Stream) URL() string {
+	if s.BaseURL != "" {
+		return s.BaseURL
+	}
+	return s.Base_url
+}
+
+// BackupURLs 返回备用流地址, 兼容两种字段命名
+func (s Stream) BackupURLs() []string {
+	if len(s.BackupURL) > 0 {
+		return s.BackupURL
+	}
+	return s.Backup_url
+}
+
+func representation(s Stream, baseURL string) Representation {
+	r := Representation{
+		ID:        fmt.Sprintf("%d-%d", s.ID, s.Codecid),
+		Bandwidth: s.Bandwidth,
+		Codecs:    s.Codecs,
+		MimeType:  firstNonEmpty(s.MimeType, s.Mime_type),
+		Width:     s.Width,
+		Height:    s.Height,
+		FrameRate: firstNonEmpty(s.FrameRate, s.Frame_rate),
+		Sar:       s.Sar,
+		BaseURL:   baseURL,
+	}
+	if s.Codecid == 0 {
+		// 音频码流的 id 即音质代码
+		r.ID = fmt.Sprintf("%d", s.ID)
+	}
+
+	seg := s.SegmentBase
+	if seg == nil {
+		seg = s.Segment_base
+	}
+	if seg != nil {
+		r.SegmentBase = &MPDSegmentBase{
+			IndexRange:     seg.IndexRange,
+			Initialization: MPDInitialization{Range: seg.Initialization},
+		}
+	}
+	return r
+}
+
+// 秒数转 ISO 8601 时长, 如 PT1M3.500S
+func formatISODuration(seconds float64) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	var b strings.Builder
+	b.WriteString("PT")
+	h := int(seconds) / 3600
+	m := int(seconds) % 3600 / 60
+	s := seconds - float64(h*3600+m*60)
+	if h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	fmt.Fprintf(&b, "%.3fS", s)
+	return b.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package video
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMPD(t *testing.T) {
+	data := &StreamData{
+		Timelength: 63500,
+		Dash: &Dash{
+			MinBufferTime: 1.5,
+			Video: []Stream{
+				{ID: 80, BaseURL: "https://upos/v80-avc.m4s", Bandwidth: 2000, Codecs: "avc1.640032", Width: 1920, Height: 1080, FrameRate: "29.970", Sar: "1:1", Codecid: 7,
+					SegmentBase: &Segment{Initialization: "0-900", IndexRange: "901-1200"}},
+				{ID: 80, Base_url: "https://upos/v80-hevc.m4s", Bandwidth: 1500, Codecs: "hev1.1.6.L150.90", Width: 1920, Height: 1080, Frame_rate: "29.970", Codecid: 12},
+				{ID: 64, BaseURL: "https://upos/v64-avc.m4s", Bandwidth: 1000, Codecs: "avc1.640028", Width: 1280, Height: 720, Codecid: 7},
+			},
+			Audio: []Stream{
+				{ID: 30216, BaseURL: "https://upos/a64.m4s", Bandwidth: 64000, Codecs: "mp4a.40.2"},
+				{ID: 30280, BaseURL: "https://upos/a192.m4s", Bandwidth: 192000, Codecs: "mp4a.40.2"},
+			},
+			Dolby: &Dolby{Audio: []Stream{
+				{ID: 30250, BaseURL: "https://upos/dolby.m4s", Bandwidth: 448000, Codecs: "ec-3"},
+			}},
+			Flac: &Flac{Audio: Stream{ID: 30251, BaseURL: "https://upos/flac.m4s", Bandwidth: 1000000, Codecs: "fLaC"}},
+		},
+	}
+
+	out, err := GenerateMPD(data, nil)
+	assert.NoError(t, err)
+
+	var mpd MPD
+	assert.NoError(t, xml.Unmarshal(out, &mpd))
+	assert.Equal(t, "PT1M3.500S", mpd.MediaPresentationDuration)
+	assert.Equal(t, "PT1.500S", mpd.MinBufferTime)
+
+	sets := mpd.Period.AdaptationSets
+	assert.Len(t, sets, 5)
+	assert.Len(t, sets[0].Representations, 2) // AVC
+	assert.Len(t, sets[1].Representations, 1) // HEVC
+	assert.Equal(t, "https://upos/v80-hevc.m4s", sets[1].Representations[0].BaseURL)
+	assert.Equal(t, "29.970", sets[1].Representations[0].FrameRate)
+	assert.Equal(t, "901-1200", sets[0].Representations[0].SegmentBase.IndexRange)
+	assert.Equal(t, "0-900", sets[0].Representations[0].SegmentBase.Initialization.Range)
+
+	// 伴音按编码分组, 每组只含一种编码
+	assert.Equal(t, "audio", sets[2].ContentType)
+	assert.Len(t, sets[2].Representations, 2)
+	assert.Equal(t, "30280", sets[2].Representations[0].ID)
+	for i, codecs := range []string{"mp4a.40.2", "ec-3", "fLaC"} {
+		set := sets[2+i]
+		assert.Equal(t, "audio", set.ContentType)
+		assert.Equal(t, 2+i, set.ID)
+		for _, r := range set.Representations {
+			assert.Equal(t, codecs, r.Codecs)
+		}
+	}
+}
+
+func TestGenerateMPDNoDash(t *testing.T) {
+	_, err := GenerateMPD(&StreamData{}, nil)
+	assert.Error(t, err)
+}
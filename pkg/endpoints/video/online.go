@@ -1,6 +1,16 @@
 package video
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
 
 // 获取视频在线人数 (Web端)
 //
@@ -80,3 +90,272 @@ type AppOnlineTotalResponse struct {
 		Online string `json:"online"` // 所有终端总计人数, 例如10万+人在看
 	} `json:"data"` // 信息本体
 }
+
+// OnlineRange 模糊在线人数对应的数值范围
+type OnlineRange struct {
+	Min int `json:"min"` // 下限
+	Max int `json:"max"` // 按显示精度估计的上限, 如 "1000+" 为 1999
+}
+
+// 解析在线人数文本
+//
+// Parameters:
+//   - s (string): 如 "123", "1000+", "1.2万+", "10万+人在看"
+//
+// 备注：
+//   - 精确数字的 Min 与 Max 相等, 带 "+" 或 "万" 的按最后一位有效数字估计上限
+//   - 无法解析时返回零值
+func ParseOnlineRange(s string) OnlineRange {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "人在看")
+	s, fuzzy := strings.CutSuffix(s, "+")
+	mul := 1.0
+	if rest, ok := strings.CutSuffix(s, "万"); ok {
+		s, mul, fuzzy = rest, 1e4, true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return OnlineRange{}
+	}
+	min := int(math.Round(f * mul))
+	if !fuzzy {
+		return OnlineRange{Min: min, Max: min}
+	}
+
+	// 最后一位有效数字的单位, 如 "1000" 为 1000, "1.2" 为 0.1
+	unit := 1.0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		unit = math.Pow(10, -float64(len(s)-i-1))
+	} else {
+		for t := strings.TrimLeft(s, "0"); strings.HasSuffix(t, "0"); t = t[:len(t)-1] {
+			unit *= 10
+		}
+	}
+	return OnlineRange{Min: min, Max: min + int(math.Round(unit*mul)) - 1}
+}
+
+// 在线人数事件类型
+const (
+	OnlineThresholdUp   = "threshold_up"   // 上升越过阈值
+	OnlineThresholdDown = "threshold_down" // 下降越过阈值
+	OnlineSpike         = "spike"          // 相对滚动窗口均值突增
+)
+
+// OnlineTarget 监控目标
+type OnlineTarget struct {
+	Aid  int    `json:"aid"`  // 稿件 avid, 与 Bvid 任选一个
+	Bvid string `json:"bvid"` // 稿件 bvid, 与 Aid 任选一个
+	Cid  int    `json:"cid"`  // 视频 cid
+}
+
+// OnlineSample 一次在线人数采样
+type OnlineSample struct {
+	Time    time.Time    `json:"time"`     // 采样时间
+	Target  OnlineTarget `json:"target"`   // 监控目标
+	Total   OnlineRange  `json:"total"`    // 所有终端总计人数
+	Web     OnlineRange  `json:"web"`      // web端实时在线人数
+	RawText string       `json:"raw_text"` // 接口返回的原始总计人数
+}
+
+// OnlineEvent 在线人数告警
+type OnlineEvent struct {
+	Type      string       `json:"type"`      // 事件类型, 见 OnlineThresholdUp 等
+	Sample    OnlineSample `json:"sample"`    // 触发事件的样本
+	Threshold int          `json:"threshold"` // 越过的阈值, 仅阈值事件
+	Baseline  float64      `json:"baseline"`  // 滚动窗口内此前样本的平均下限, 仅突增事件
+}
+
+// OnlineMonitor 定时采集一组视频的在线人数, 越过阈值或突增时告警
+//
+// 备注：
+//   - 比较均使用范围下限 OnlineRange.Min
+//   - 突增需窗口内至少有2个此前样本, 且同时满足 SpikeRatio 与 SpikeMin
+//   - 设置 Webhook 后每个事件以 JSON POST 到该地址, 失败时调用 OnError
+type OnlineMonitor struct {
+	Targets         []OnlineTarget // 监控目标
+	Interval        time.Duration  // 采样周期, 不大于0时为1分钟
+	RequestInterval time.Duration  // 两次请求的最小间隔, 用于限速
+	Window          int            // 滚动窗口的样本数, 默认为10
+	Thresholds      []int          // 告警阈值
+	SpikeRatio      float64        // 当前值与窗口均值之比达到该值视为突增, 0 表示不检测
+	SpikeMin        int            // 突增的最小绝对增量
+	Webhook         string         // 事件推送地址 (可选)
+
+	OnSample func(sample OnlineSample)       // 每次采样后回调 (可选)
+	OnEvent  func(event OnlineEvent)         // 事件回调 (可选)
+	OnError  func(t OnlineTarget, err error) // 采样或推送出错回调 (可选)
+
+	online   func(aid int, bvid string, cid int) (*OnlineTotalResponse, error)
+	post     func(url string, event OnlineEvent) error
+	throttle *misc.Throttle
+
+	mu      sync.Mutex
+	windows map[OnlineTarget][]OnlineSample
+}
+
+// 创建在线人数监控
+//
+// Parameters:
+//   - targets ([]OnlineTarget): 监控目标
+//   - interval (time.Duration): 采样周期
+//
+// 备注：
+//   - 默认请求间隔为 1 秒
+func (v *Video) NewOnlineMonitor(targets []OnlineTarget, interval time.Duration) *OnlineMonitor {
+	return &OnlineMonitor{
+		Targets:         targets,
+		Interval:        interval,
+		RequestInterval: time.Second,
+		Window:          10,
+		online:          v.OnlineTotal,
+		post: func(url string, event OnlineEvent) error {
+			resp, err := v.client.HTTPClient.R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(event).
+				Post(url)
+			if err != nil {
+				return err
+			}
+			if resp.IsError() {
+				return fmt.Errorf("webhook %s: %s", url, resp.Status())
+			}
+			return nil
+		},
+		windows: map[OnlineTarget][]OnlineSample{},
+	}
+}
+
+// Run 按周期采样, 直到 ctx 结束
+func (m *OnlineMonitor) Run(ctx context.Context) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.PollOnce(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollOnce 对全部目标采样一次并检测事件, 返回本轮成功的样本
+//
+// 备注：
+//   - 单个目标出错不会中断采样, 仅在 ctx 结束时返回错误
+func (m *OnlineMonitor) PollOnce(ctx context.Context) ([]OnlineSample, error) {
+	if m.throttle == nil {
+		m.throttle = &misc.Throttle{Interval: m.RequestInterval}
+	}
+
+	var samples []OnlineSample
+	for _, t := range m.Targets {
+		if err := m.throttle.Wait(ctx); err != nil {
+			return samples, err
+		}
+		sample, err := m.sample(t)
+		if err != nil {
+			if m.OnError != nil {
+				m.OnError(t, err)
+			}
+			continue
+		}
+
+		events := m.observe(sample)
+		if m.OnSample != nil {
+			m.OnSample(sample)
+		}
+		for _, e := range events {
+			m.emit(e)
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// History 返回某个目标滚动窗口内的样本
+func (m *OnlineMonitor) History(t OnlineTarget) []OnlineSample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OnlineSample(nil), m.windows[t]...)
+}
+
+func (m *OnlineMonitor) sample(t OnlineTarget) (OnlineSample, error) {
+	resp, err := m.online(t.Aid, t.Bvid, t.Cid)
+	if err != nil {
+		return OnlineSample{}, err
+	}
+	if resp.Code != 0 {
+		return OnlineSample{}, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+	}
+	return OnlineSample{
+		Time:    time.Now(),
+		Target:  t,
+		Total:   ParseOnlineRange(resp.Data.Total),
+		Web:     ParseOnlineRange(resp.Data.Count),
+		RawText: resp.Data.Total,
+	}, nil
+}
+
+// 记录样本并返回触发的事件
+func (m *OnlineMonitor) observe(s OnlineSample) []OnlineEvent {
+	m.mu.Lock()
+	prev := m.windows[s.Target]
+	window := m.Window
+	if window <= 0 {
+		window = 10
+	}
+	list := append(prev, s)
+	if len(list) > window {
+		list = list[len(list)-window:]
+	}
+	m.windows[s.Target] = list
+	m.mu.Unlock()
+
+	var events []OnlineEvent
+	cur := s.Total.Min
+	if len(prev) > 0 {
+		last := prev[len(prev)-1].Total.Min
+		for _, th := range m.Thresholds {
+			switch {
+			case last < th && cur >= th:
+				events = append(events, OnlineEvent{Type: OnlineThresholdUp, Sample: s, Threshold: th})
+			case last >= th && cur < th:
+				events = append(events, OnlineEvent{Type: OnlineThresholdDown, Sample: s, Threshold: th})
+			}
+		}
+	}
+
+	if len(prev) > window-1 {
+		prev = prev[len(prev)-(window-1):]
+	}
+	if m.SpikeRatio > 0 && len(prev) >= 2 {
+		sum := 0
+		for _, p := range prev {
+			sum += p.Total.Min
+		}
+		base := float64(sum) / float64(len(prev))
+		if float64(cur) >= base*m.SpikeRatio && float64(cur)-base >= float64(m.SpikeMin) && cur > 0 {
+			events = append(events, OnlineEvent{Type: OnlineSpike, Sample: s, Baseline: base})
+		}
+	}
+	return events
+}
+
+func (m *OnlineMonitor) emit(e OnlineEvent) {
+	if m.OnEvent != nil {
+		m.OnEvent(e)
+	}
+	if m.Webhook == "" {
+		return
+	}
+	if err := m.post(m.Webhook, e); err != nil && m.OnError != nil {
+		m.OnError(e.Sample.Target, err)
+	}
+}
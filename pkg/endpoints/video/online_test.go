@@ -0,0 +1,88 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOnlineRange(t *testing.T) {
+	cases := map[string]OnlineRange{
+		"123":     {123, 123},
+		"1000+":   {1000, 1999},
+		"3000+":   {3000, 3999},
+		"1万+":     {10000, 19999},
+		"1.2万+":   {12000, 12999},
+		"10万+人在看": {100000, 199999},
+		" 100万+ ": {1000000, 1999999},
+		"":        {},
+		"--":      {},
+	}
+	for s, want := range cases {
+		assert.Equal(t, want, ParseOnlineRange(s), s)
+	}
+}
+
+func TestOnlineMonitor(t *testing.T) {
+	totals := []string{"500", "800", "1000+", "900", "5000+"}
+	i := 0
+	target := OnlineTarget{Bvid: "BV1xx", Cid: 1}
+
+	var events []OnlineEvent
+	var posted []string
+	var errs []error
+	m := &OnlineMonitor{
+		Targets:    []OnlineTarget{target},
+		Window:     3,
+		Thresholds: []int{1000},
+		SpikeRatio: 3,
+		SpikeMin:   1000,
+		Webhook:    "http://hook",
+		OnEvent:    func(e OnlineEvent) { events = append(events, e) },
+		OnError:    func(_ OnlineTarget, err error) { errs = append(errs, err) },
+		online: func(aid int, bvid string, cid int) (*OnlineTotalResponse, error) {
+			resp := &OnlineTotalResponse{}
+			resp.Data.Total = totals[i]
+			i++
+			return resp, nil
+		},
+		post: func(url string, e OnlineEvent) error {
+			posted = append(posted, e.Type)
+			if e.Type == OnlineSpike {
+				return errors.New("hook down")
+			}
+			return nil
+		},
+		windows: map[OnlineTarget][]OnlineSample{},
+	}
+
+	for range totals {
+		_, err := m.PollOnce(context.Background())
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, events, 4)
+	assert.Equal(t, OnlineThresholdUp, events[0].Type)
+	assert.Equal(t, "1000+", events[0].Sample.RawText)
+	assert.Equal(t, OnlineThresholdDown, events[1].Type)
+	assert.Equal(t, OnlineThresholdUp, events[2].Type)
+	// 5000 对比窗口内此前 1000, 900 的均值 950
+	assert.Equal(t, OnlineSpike, events[3].Type)
+	assert.Equal(t, 950.0, events[3].Baseline)
+	assert.Equal(t, []string{OnlineThresholdUp, OnlineThresholdDown, OnlineThresholdUp, OnlineSpike}, posted)
+	assert.EqualError(t, errs[0], "hook down")
+	assert.Len(t, m.History(target), 3)
+}
+
+func TestOnlineMonitorRunZeroInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := New(nil).NewOnlineMonitor([]OnlineTarget{{Aid: 1, Cid: 1}}, 0)
+	m.RequestInterval = 0
+	m.online = func(aid int, bvid string, cid int) (*OnlineTotalResponse, error) {
+		return &OnlineTotalResponse{}, nil
+	}
+	m.OnSample = func(OnlineSample) { cancel() }
+	assert.ErrorIs(t, m.Run(ctx), context.Canceled)
+}
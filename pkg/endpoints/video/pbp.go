@@ -1,6 +1,14 @@
 package video
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
 
 // 获取弹幕趋势顶点列表
 // Parameters:
@@ -28,11 +36,265 @@ func (v *Video) GetHighEnergyProgress(cid int, aid int, bvid string) (*HighEnerg
 	return resp.Result().(*HighEnergyProgressResponse), nil
 }
 
+// 获取高能进度条并提取高光片段, 附带所在章节
+//
+// Parameters:
+//   - aid (int): 视频的aid, 与 bvid 任选一个
+//   - bvid (string): 视频的bvid, 与 aid 任选一个
+//   - cid (int): 视频的cid
+//   - opts (HighlightOptions): 提取参数, 零值使用默认参数
+//
+// Authentication:
+//   - 鉴权方式：Wbi 签名 (获取章节)
+//
+// 备注：
+//   - 弹幕密度需另行获取弹幕后调用 Highlights.Align 补充
+func (v *Video) HighEnergyHighlights(aid int, bvid string, cid int, opts HighlightOptions) (Highlights, error) {
+	pbp, err := v.GetHighEnergyProgress(cid, aid, bvid)
+	if err != nil {
+		return nil, err
+	}
+	hs := pbp.Highlights(opts)
+
+	player, err := v.PlayerInfo(aid, bvid, cid)
+	if err != nil {
+		return nil, err
+	}
+	return hs.alignPlayer(player)
+}
+
+// 按播放器信息中的章节对齐, 接口出错时返回 CodeError
+func (hs Highlights) alignPlayer(player *WebPlayerInfoResponse) (Highlights, error) {
+	if player.Code != 0 {
+		return nil, &misc.CodeError{Code: player.Code, Message: player.Message}
+	}
+	return hs.Align(player.Data.ViewPoints, nil), nil
+}
+
 type HighEnergyProgressResponse struct {
 	StepSec int    `json:"step_sec"` // 采样间隔时间, 单位为秒, 由视频时长决定
 	TagStr  string `json:"tagstr"`   // ？？？ 作用尚不明确
 	Events  struct {
 		Default []int `json:"default"` // 顶点值列表
 	} `json:"events"` // 数据本体
-	Debug string `json:"debug"` // 调试信息, json字符串
+	Debug string `json:"debug"` // 调试信息, json字符串, 见 DebugInfo
+}
+
+// PbpDebug 高能进度条调试信息
+type PbpDebug struct {
+	MaxTime int   `json:"max_time"` // 曲线覆盖的时长, 单位为秒
+	IsRec   bool  `json:"is_rec"`   // 作用尚不明确
+	Uptime  int64 `json:"uptime"`   // 数据更新时间, 秒级时间戳
+}
+
+// DebugInfo 解析 Debug 字段, 为空时返回 nil
+func (r *HighEnergyProgressResponse) DebugInfo() (*PbpDebug, error) {
+	if r.Debug == "" {
+		return nil, nil
+	}
+	var d PbpDebug
+	if err := json.Unmarshal([]byte(r.Debug), &d); err != nil {
+		return nil, fmt.Errorf("invalid pbp debug: %w", err)
+	}
+	return &d, nil
+}
+
+// Curve 返回归一化到 [0, 1] 的曲线, 第 i 个点对应 i*StepSec 秒
+//
+// Parameters:
+//   - smooth (int): 滑动平均窗口 (采样点数), 0或1为不平滑
+func (r *HighEnergyProgressResponse) Curve(smooth int) []float64 {
+	raw := r.Events.Default
+	curve := make([]float64, len(raw))
+	for i := range raw {
+		if smooth <= 1 {
+			curve[i] = float64(raw[i])
+			continue
+		}
+		lo, hi := i-smooth/2, i+(smooth-1)/2
+		if lo < 0 {
+			lo = 0
+		}
+		if hi >= len(raw) {
+			hi = len(raw) - 1
+		}
+		sum := 0
+		for _, v := range raw[lo : hi+1] {
+			sum += v
+		}
+		curve[i] = float64(sum) / float64(hi-lo+1)
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range curve {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	for i := range curve {
+		if max > min {
+			curve[i] = (curve[i] - min) / (max - min)
+		} else {
+			curve[i] = 0
+		}
+	}
+	return curve
+}
+
+// HighlightOptions 高光片段提取参数
+type HighlightOptions struct {
+	Threshold float64 // 归一化曲线的阈值, 高于该值的连续区间视为高光, 默认为0.6
+	Smooth    int     // 滑动平均窗口 (采样点数), 0或1为不平滑
+	MergeGap  float64 // 间隔不超过该秒数的片段合并为一个
+	MinLength float64 // 短于该秒数的片段被丢弃
+	Padding   float64 // 片段前后各扩展的秒数
+	MaxCount  int     // 按分数保留的最多片段数, 0 表示不限制
+}
+
+// Highlight 高光片段
+type Highlight struct {
+	Start          float64 `json:"start"`             // 开始时间, 单位为秒
+	End            float64 `json:"end"`               // 结束时间, 单位为秒
+	Peak           float64 `json:"peak"`              // 峰值所在时间, 单位为秒
+	Score          float64 `json:"score"`             // 峰值的归一化分数, 0~1
+	Chapter        string  `json:"chapter,omitempty"` // 峰值所在章节名
+	Danmaku        int     `json:"danmaku"`           // 片段内弹幕数
+	DanmakuDensity float64 `json:"danmaku_density"`   // 片段内每秒弹幕数
+}
+
+// Highlights 按时间排序的高光片段
+type Highlights []Highlight
+
+// Highlights 从曲线中提取高光片段
+func (r *HighEnergyProgressResponse) Highlights(opts HighlightOptions) Highlights {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 0.6
+	}
+	step := float64(r.StepSec)
+	if step <= 0 {
+		return nil
+	}
+	curve := r.Curve(opts.Smooth)
+	total := step * float64(len(curve))
+
+	var hs Highlights
+	for i := 0; i < len(curve); i++ {
+		if curve[i] < opts.Threshold {
+			continue
+		}
+		h := Highlight{Start: float64(i) * step, Peak: float64(i) * step, Score: curve[i]}
+		for ; i < len(curve) && curve[i] >= opts.Threshold; i++ {
+			if curve[i] > h.Score {
+				h.Score, h.Peak = curve[i], float64(i)*step
+			}
+		}
+		h.End = float64(i) * step
+
+		if n := len(hs); n > 0 && h.Start-hs[n-1].End <= opts.MergeGap {
+			last := &hs[n-1]
+			last.End = h.End
+			if h.Score > last.Score {
+				last.Score, last.Peak = h.Score, h.Peak
+			}
+			continue
+		}
+		hs = append(hs, h)
+	}
+
+	kept := hs[:0]
+	for _, h := range hs {
+		if h.End-h.Start < opts.MinLength {
+			continue
+		}
+		h.Start = math.Max(0, h.Start-opts.Padding)
+		h.End = math.Min(total, h.End+opts.Padding)
+		kept = append(kept, h)
+	}
+	hs = kept
+
+	if opts.MaxCount > 0 && len(hs) > opts.MaxCount {
+		sort.SliceStable(hs, func(i, j int) bool { return hs[i].Score > hs[j].Score })
+		hs = hs[:opts.MaxCount]
+		sort.SliceStable(hs, func(i, j int) bool { return hs[i].Start < hs[j].Start })
+	}
+	return hs
+}
+
+// Align 补充章节与弹幕密度
+//
+// Parameters:
+//   - points ([]ViewPoint): 章节看点, 见 WebPlayerInfoResponse 或 SummaryModelResult.ViewPoints
+//   - danmaku ([]float64): 弹幕出现时间, 单位为秒, 可由 danmuku.Danmaku.Time 得到
+func (hs Highlights) Align(points []ViewPoint, danmaku []float64) Highlights {
+	for i := range hs {
+		h := &hs[i]
+		for _, p := range points {
+			if float64(p.From) <= h.Peak && h.Peak < float64(p.To) {
+				h.Chapter = p.Content
+				break
+			}
+		}
+		if danmaku == nil {
+			continue
+		}
+		h.Danmaku = 0
+		for _, t := range danmaku {
+			if h.Start <= t && t < h.End {
+				h.Danmaku++
+			}
+		}
+		if d := h.End - h.Start; d > 0 {
+			h.DanmakuDensity = float64(h.Danmaku) / d
+		}
+	}
+	return hs
+}
+
+// WriteJSON 导出为 JSON 数组
+func (hs Highlights) WriteJSON(w io.Writer) error {
+	if hs == nil {
+		hs = Highlights{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(hs)
+}
+
+// WriteEDL 导出为 CMX3600 EDL, 可导入 Premiere / DaVinci Resolve 等剪辑软件
+//
+// Parameters:
+//   - title (string): EDL 标题
+//   - clip (string): 源素材文件名, 写入 FROM CLIP NAME
+//   - fps (int): 时间码帧率, 默认为30
+func (hs Highlights) WriteEDL(w io.Writer, title, clip string, fps int) error {
+	if fps <= 0 {
+		fps = 30
+	}
+	ew := &errWriter{w: w}
+	ew.printf("TITLE: %s\nFCM: NON-DROP FRAME\n\n", title)
+
+	record := 0.0
+	for i, h := range hs {
+		length := h.End - h.Start
+		ew.printf("%03d  AX       V     C        %s %s %s %s\n", i+1,
+			timecode(h.Start, fps), timecode(h.End, fps),
+			timecode(record, fps), timecode(record+length, fps))
+		if clip != "" {
+			ew.printf("* FROM CLIP NAME: %s\n", clip)
+		}
+		comment := fmt.Sprintf("SCORE %.2f", h.Score)
+		if h.Chapter != "" {
+			comment += " " + h.Chapter
+		}
+		ew.printf("* COMMENT: %s\n\n", comment)
+		record += length
+	}
+	return ew.err
+}
+
+// 秒数转为 HH:MM:SS:FF 时间码
+func timecode(sec float64, fps int) string {
+	frames := int(math.Round(sec * float64(fps)))
+	ff := frames % fps
+	s := frames / fps
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", s/3600, s/60%60, s%60, ff)
 }
@@ -0,0 +1,79 @@
+package video
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighEnergyHighlights(t *testing.T) {
+	pbp := &HighEnergyProgressResponse{
+		StepSec: 10,
+		Debug:   `{"max_time":120,"is_rec":false,"uptime":1700000000}`,
+	}
+	//                            0   10  20  30  40  50  60  70  80  90 100 110
+	pbp.Events.Default = []int{10, 10, 90, 110, 10, 100, 10, 10, 60, 10, 10, 10}
+
+	debug, err := pbp.DebugInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 120, debug.MaxTime)
+	assert.Equal(t, int64(1700000000), debug.Uptime)
+
+	curve := pbp.Curve(0)
+	assert.Equal(t, 0.0, curve[0])
+	assert.Equal(t, 1.0, curve[3])
+	assert.Equal(t, 1.0, pbp.Curve(3)[4]) // 平滑后 (110+10+100)/3 最高
+
+	hs := pbp.Highlights(HighlightOptions{})
+	assert.Equal(t, Highlights{
+		{Start: 20, End: 40, Peak: 30, Score: 1},
+		{Start: 50, End: 60, Peak: 50, Score: 0.9},
+	}, hs)
+
+	hs = pbp.Highlights(HighlightOptions{Threshold: 0.5, MergeGap: 10, Padding: 5, MaxCount: 1})
+	assert.Equal(t, Highlights{{Start: 15, End: 65, Peak: 30, Score: 1}}, hs)
+
+	hs = pbp.Highlights(HighlightOptions{MinLength: 15})
+	assert.Len(t, hs, 1)
+
+	hs = pbp.Highlights(HighlightOptions{}).Align(
+		[]ViewPoint{{Content: "开场", From: 0, To: 45}, {Content: "高潮", From: 45, To: 120}},
+		[]float64{1, 21, 22, 39.9, 40, 55},
+	)
+	assert.Equal(t, "开场", hs[0].Chapter)
+	assert.Equal(t, 3, hs[0].Danmaku)
+	assert.InDelta(t, 0.15, hs[0].DanmakuDensity, 1e-9)
+	assert.Equal(t, "高潮", hs[1].Chapter)
+	assert.Equal(t, 1, hs[1].Danmaku)
+
+	var edl bytes.Buffer
+	assert.NoError(t, hs.WriteEDL(&edl, "BV1xx", "BV1xx.mp4", 25))
+	assert.Equal(t, "TITLE: BV1xx\nFCM: NON-DROP FRAME\n\n"+
+		"001  AX       V     C        00:00:20:00 00:00:40:00 00:00:00:00 00:00:20:00\n"+
+		"* FROM CLIP NAME: BV1xx.mp4\n* COMMENT: SCORE 1.00 开场\n\n"+
+		"002  AX       V     C        00:00:50:00 00:01:00:00 00:00:20:00 00:00:30:00\n"+
+		"* FROM CLIP NAME: BV1xx.mp4\n* COMMENT: SCORE 0.90 高潮\n\n", edl.String())
+
+	var js bytes.Buffer
+	assert.NoError(t, Highlights(nil).WriteJSON(&js))
+	assert.Equal(t, "[]\n", js.String())
+	assert.Equal(t, "00:01:01:12", timecode(61.5, 24))
+}
+
+func TestHighlightsAlignPlayer(t *testing.T) {
+	hs := Highlights{{Start: 20, End: 40, Peak: 30, Score: 1}}
+
+	player := &WebPlayerInfoResponse{Code: -400, Message: "请求错误"}
+	_, err := hs.alignPlayer(player)
+	var ce *misc.CodeError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, -400, ce.Code)
+
+	player = &WebPlayerInfoResponse{}
+	player.Data.ViewPoints = []ViewPoint{{Content: "开场", From: 0, To: 45}}
+	aligned, err := hs.alignPlayer(player)
+	assert.NoError(t, err)
+	assert.Equal(t, "开场", aligned[0].Chapter)
+}
@@ -1,6 +1,12 @@
 package video
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+)
 
 // GetWebPlayerInfo retrieves web player information.
 // Parameters:
@@ -28,6 +34,10 @@ func (v *Video) GetWebPlayerInfo(aid int, bvid string, cid int, wRid string, wts
 	resp, err := v.client.HTTPClient.R().
 		SetQueryParams(queryParams).
 		SetResult(&WebPlayerInfoResponse{}).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
 		Get(baseURL)
 	if err != nil {
 		return nil, err
@@ -36,29 +46,201 @@ func (v *Video) GetWebPlayerInfo(aid int, bvid string, cid int, wRid string, wts
 	return resp.Result().(*WebPlayerInfoResponse), nil
 }
 
+// 获取 Web 端播放器信息 (自动 Wbi 签名)
+//
+// Parameters:
+//   - aid (int): 视频的 aid, 与 bvid 任选一个
+//   - bvid (string): 视频的 bvid, 与 aid 任选一个
+//   - cid (int): 视频的 cid (必要)
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 未登录时字幕列表为空
+//   - 鉴权方式：Wbi 签名
+//
+// 备注：
+//   - 与 GetWebPlayerInfo 相同, 但由客户端计算签名, 未签名的请求可能被风控或返回不完整的数据
+func (v *Video) PlayerInfo(aid int, bvid string, cid int) (*WebPlayerInfoResponse, error) {
+	baseURL := "https://api.bilibili.com/x/player/wbi/v2"
+
+	params := url.Values{}
+	if aid != 0 {
+		params.Set("aid", fmt.Sprintf("%d", aid))
+	}
+	if bvid != "" {
+		params.Set("bvid", bvid)
+	}
+	params.Set("cid", fmt.Sprintf("%d", cid))
+
+	newUrl, err := login.New(v.client).SignAndGenerateURL(baseURL + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("User-Agent", v.client.UserAgent).
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetResult(&WebPlayerInfoResponse{}).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		Get(newUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*WebPlayerInfoResponse), nil
+}
+
 // WebPlayerInfoResponse represents the response structure for the web player info API.
 type WebPlayerInfoResponse struct {
 	Code    int    `json:"code"`    // 返回值, 0: 成功, -400: 请求错误
 	Message string `json:"message"` // 错误信息, 默认为 0
 	TTL     int    `json:"ttl"`     // 默认值为 1
 	Data    struct {
-		AID        int          `json:"aid"`      // 视频 aid
-		BVID       string       `json:"bvid"`     // 视频 bvid
-		CID        int          `json:"cid"`      // 视频 cid
-		DMMask     *DMMask      `json:"dm_mask"`  // webmask 信息 (如果没有这一项，说明这个视频没有防挡功能)
-		Subtitle   *WebSubtitle `json:"subtitle"` // 字幕信息 (需要登录，不登录此项内容为 [])
-		ViewPoints []struct {
-			Content string `json:"content"` // 章节名
-			From    int    `json:"from"`    // 开始时间, 单位为秒
-			To      int    `json:"to"`      // 结束时间, 单位为秒
-			Type    int    `json:"type"`    // 类型, 具体含义视实现而定
-			ImgURL  string `json:"imgUrl"`  // 图片资源地址
-			LogoURL string `json:"logoUrl"` // Logo 资源地址, 如果为空则为 ""
-		} `json:"view_points"` // 章节看点信息
-		// 其他字段略去...
+		AID               int               `json:"aid"`                  // 视频 aid
+		BVID              string            `json:"bvid"`                 // 视频 bvid
+		AllowBP           bool              `json:"allow_bp"`             // 是否允许承包
+		NoShare           bool              `json:"no_share"`             // 是否禁止分享
+		CID               int               `json:"cid"`                  // 视频 cid
+		MaxLimit          int               `json:"max_limit"`            // 弹幕池上限
+		PageNo            int               `json:"page_no"`              // 当前分P序号
+		HasNext           bool              `json:"has_next"`             // 是否有下一P
+		IPInfo            PlayerIPInfo      `json:"ip_info"`              // 请求者 IP 信息
+		LoginMid          int               `json:"login_mid"`            // 登录用户 mid, 未登录为 0
+		LoginMidHash      string            `json:"login_mid_hash"`       // 登录用户 mid 哈希, 用于弹幕
+		IsOwner           bool              `json:"is_owner"`             // 是否为 UP 主本人
+		Name              string            `json:"name"`                 // 登录用户昵称
+		Permission        string            `json:"permission"`           // 登录用户权限
+		LevelInfo         LevelInfo         `json:"level_info"`           // 登录用户等级
+		Vip               Vip               `json:"vip"`                  // 登录用户大会员状态
+		AnswerStatus      int               `json:"answer_status"`        // 答题状态
+		BlockTime         int               `json:"block_time"`           // 封禁时间
+		Role              string            `json:"role"`                 // 登录用户角色
+		LastPlayTime      int               `json:"last_play_time"`       // 上次播放进度, 单位为毫秒
+		LastPlayCid       int               `json:"last_play_cid"`        // 上次播放分P的 cid
+		NowTime           int               `json:"now_time"`             // 服务器当前时间戳
+		OnlineCount       int               `json:"online_count"`         // 当前在线人数
+		NeedLoginSubtitle bool              `json:"need_login_subtitle"`  // 是否需要登录才能查看字幕
+		DMMask            *DMMask           `json:"dm_mask"`              // webmask 信息 (如果没有这一项，说明这个视频没有防挡功能)
+		Subtitle          *WebSubtitle      `json:"subtitle"`             // 字幕信息 (需要登录，不登录此项内容为 [])
+		ViewPoints        []ViewPoint       `json:"view_points"`          // 章节看点信息
+		PreviewToast      string            `json:"preview_toast"`        // 试看提示
+		Options           PlayerOptions     `json:"options"`              // 播放器选项
+		GuideAttention    []GuideAttention  `json:"guide_attention"`      // 关注引导浮层
+		JumpCard          []JumpCard        `json:"jump_card"`            // 跳转卡片
+		OperationCard     []OperationCard   `json:"operation_card"`       // 运营卡片
+		OnlineSwitch      map[string]string `json:"online_switch"`        // 在线功能开关, 值为 "0"/"1" 等字符串
+		Fawkes            PlayerFawkes      `json:"fawkes"`               // 播放器配置版本
+		ShowSwitch        PlayerShowSwitch  `json:"show_switch"`          // 显示开关
+		BgmInfo           *BgmInfo          `json:"bgm_info"`             // 背景音乐信息 (无背景音乐时为 null)
+		ToastBlock        bool              `json:"toast_block"`          // 是否屏蔽提示
+		IsUpowerExclusive bool              `json:"is_upower_exclusive"`  // 是否为充电专属视频
+		IsUpowerPlay      bool              `json:"is_upower_play"`       // 是否可以观看充电专属视频
+		IsUgcPayPreview   bool              `json:"is_ugc_pay_preview"`   // 是否为付费视频试看
+		ElecHighLevel     *ElecHighLevel    `json:"elec_high_level"`      // 充电专属信息
+		DisableShowUpInfo bool              `json:"disable_show_up_info"` // 是否隐藏 UP 主信息
+		Interaction       *Interaction      `json:"interaction"`          // 互动视频信息 (非互动视频无此项)
 	} `json:"data"` // 数据本体
 }
 
+// ViewPoint represents a chapter marker in the web player info.
+type ViewPoint struct {
+	Content string `json:"content"` // 章节名
+	From    int    `json:"from"`    // 开始时间, 单位为秒
+	To      int    `json:"to"`      // 结束时间, 单位为秒
+	Type    int    `json:"type"`    // 类型, 具体含义视实现而定
+	ImgURL  string `json:"imgUrl"`  // 图片资源地址
+	LogoURL string `json:"logoUrl"` // Logo 资源地址, 如果为空则为 ""
+}
+
+// PlayerIPInfo represents the requester's IP information in the web player info.
+type PlayerIPInfo struct {
+	IP       string `json:"ip"`       // 请求者 IP
+	ZoneIP   string `json:"zone_ip"`  // 地区 IP
+	ZoneID   int    `json:"zone_id"`  // 地区 id
+	Country  string `json:"country"`  // 国家
+	Province string `json:"province"` // 省份
+	City     string `json:"city"`     // 城市
+}
+
+// PlayerOptions represents the player options in the web player info.
+type PlayerOptions struct {
+	Is360      bool `json:"is_360"`      // 是否为全景视频
+	WithoutVip bool `json:"without_vip"` // 是否不需要大会员
+}
+
+// GuideAttention represents a follow-guide overlay shown during playback.
+type GuideAttention struct {
+	Type int     `json:"type"`  // 类型, 作用尚不明确
+	From int     `json:"from"`  // 开始时间, 单位为秒
+	To   int     `json:"to"`    // 结束时间, 单位为秒
+	PosX float64 `json:"pos_x"` // 横坐标
+	PosY float64 `json:"pos_y"` // 纵坐标
+}
+
+// JumpCard represents a jump card shown during playback.
+type JumpCard struct {
+	ID       int    `json:"id"`        // 卡片 id
+	From     int    `json:"from"`      // 开始时间, 单位为秒
+	To       int    `json:"to"`        // 结束时间, 单位为秒
+	Icon     string `json:"icon"`      // 图标 url
+	Label    string `json:"label"`     // 标签
+	Content  string `json:"content"`   // 卡片文字
+	JumpURL  string `json:"jump_url"`  // 跳转 url
+	CardType int    `json:"card_type"` // 卡片类型, 作用尚不明确
+	BizID    int    `json:"biz_id"`    // 业务 id, 作用尚不明确
+}
+
+// OperationCard represents an operation card shown during playback.
+type OperationCard struct {
+	ID           int           `json:"id"`            // 卡片 id
+	From         int           `json:"from"`          // 开始时间, 单位为秒
+	To           int           `json:"to"`            // 结束时间, 单位为秒
+	Status       bool          `json:"status"`        // 是否已操作 (如已关注)
+	CardType     int           `json:"card_type"`     // 卡片类型, 作用尚不明确
+	BizType      int           `json:"biz_type"`      // 业务类型, 作用尚不明确
+	StandardCard *StandardCard `json:"standard_card"` // 标准卡片内容, 仅部分卡片类型存在
+}
+
+// StandardCard represents the content of a standard operation card.
+type StandardCard struct {
+	Title               string `json:"title"`                 // 标题
+	ButtonTitle         string `json:"button_title"`          // 按钮文字
+	ButtonSelectedTitle string `json:"button_selected_title"` // 按钮已选中时的文字
+	ShowSelected        bool   `json:"show_selected"`         // 是否显示已选中状态
+}
+
+// PlayerFawkes represents the player config version in the web player info.
+type PlayerFawkes struct {
+	ConfigVersion int `json:"config_version"` // 配置版本
+	FFVersion     int `json:"ff_version"`     // 作用尚不明确
+}
+
+// PlayerShowSwitch represents display switches in the web player info.
+type PlayerShowSwitch struct {
+	LongProgress bool `json:"long_progress"` // 是否显示长进度条
+}
+
+// BgmInfo represents the background music used in the video.
+type BgmInfo struct {
+	MusicID    string `json:"music_id"`    // 音乐 id
+	MusicTitle string `json:"music_title"` // 音乐标题
+	JumpURL    string `json:"jump_url"`    // 音乐页面 url
+}
+
+// ElecHighLevel represents the charging-exclusive information in the web player info.
+type ElecHighLevel struct {
+	PrivilegeType int    `json:"privilege_type"` // 充电档位
+	Title         string `json:"title"`          // 标题
+	SubTitle      string `json:"sub_title"`      // 副标题
+	ShowButton    bool   `json:"show_button"`    // 是否显示按钮
+	ButtonText    string `json:"button_text"`    // 按钮文字
+	JumpURL       string `json:"jump_url"`       // 跳转 url
+	Intro         string `json:"intro"`          // 介绍
+	New           bool   `json:"new"`            // 作用尚不明确
+}
+
 // DMMask represents the webmask information in the web player info.
 type DMMask struct {
 	CID     int    `json:"cid"`      // 视频 cid
@@ -70,18 +252,21 @@ type DMMask struct {
 
 // Subtitle represents the subtitle information in the web player info.
 type WebSubtitle struct {
-	AllowSubmit bool   `json:"allow_submit"` // 是否允许提交字幕
-	Lan         string `json:"lan"`          // 语言类型英文字母缩写
+	AllowSubmit bool              `json:"allow_submit"` // 是否允许提交字幕
+	Lan         string            `json:"lan"`          // 语言类型英文字母缩写
+	LanDoc      string            `json:"lan_doc"`      // 语言类型中文名称
+	Subtitles   []WebSubtitleItem `json:"subtitles"`    // 字幕列表
+}
+
+// WebSubtitleItem represents a single subtitle track in the web player info.
+type WebSubtitleItem struct {
+	AIStatus    int    `json:"ai_status"`    // AI 状态, 0: 非 AI 字幕, 2: 已生成
+	AIType      int    `json:"ai_type"`      // AI 类型, 0: 非 AI 字幕, 1: AI 生成
+	ID          int    `json:"id"`           // 字幕 ID
+	IDStr       string `json:"id_str"`       // 字符串形式的字幕 ID
+	IsLock      bool   `json:"is_lock"`      // 是否锁定
+	Lan         string `json:"lan"`          // 语言类型英文字母缩写, AI 字幕以 ai- 开头
 	LanDoc      string `json:"lan_doc"`      // 语言类型中文名称
-	Subtitles   []struct {
-		AIStatus    int    `json:"ai_status"`    // AI 状态
-		AIType      int    `json:"ai_type"`      // AI 类型
-		ID          int    `json:"id"`           // 字幕 ID
-		IDStr       string `json:"id_str"`       // 字符串形式的字幕 ID
-		IsLock      bool   `json:"is_lock"`      // 是否锁定
-		Lan         string `json:"lan"`          // 语言类型英文字母缩写
-		LanDoc      string `json:"lan_doc"`      // 语言类型中文名称
-		SubtitleURL string `json:"subtitle_url"` // 字幕资源 URL 地址
-		Type        int    `json:"type"`         // 类型
-	} `json:"subtitles"` // 字幕列表
+	SubtitleURL string `json:"subtitle_url"` // 字幕资源 URL 地址
+	Type        int    `json:"type"`         // 类型
 }
@@ -0,0 +1,374 @@
+package video
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// RelatedNode 推荐图中的视频
+type RelatedNode struct {
+	Aid       int    `json:"aid"`        // 稿件 avid
+	Bvid      string `json:"bvid"`       // 稿件 bvid
+	Title     string `json:"title"`      // 视频标题
+	Tname     string `json:"tname"`      // 分区名称
+	OwnerMid  int    `json:"owner_mid"`  // UP 主 mid
+	OwnerName string `json:"owner_name"` // UP 主昵称
+	View      int    `json:"view"`       // 播放数
+	Duration  int    `json:"duration"`   // 视频时长, 单位为秒
+	Pubdate   int    `json:"pubdate"`    // 发布时间
+	Depth     int    `json:"depth"`      // 与种子的距离, 种子为0
+}
+
+// RelatedEdge 推荐图中的边, 表示 From 的推荐列表中出现了 To
+type RelatedEdge struct {
+	From int `json:"from"` // 来源稿件 avid
+	To   int `json:"to"`   // 被推荐稿件 avid
+	Rank int `json:"rank"` // 在推荐列表中的位置, 从0开始
+}
+
+// RelatedGraph 视频推荐图
+type RelatedGraph struct {
+	Nodes []RelatedNode `json:"nodes"` // 按发现顺序排列
+	Edges []RelatedEdge `json:"edges"` // 按发现顺序排列
+}
+
+// RelatedCrawler 从种子视频出发, 广度优先遍历推荐列表, 构建推荐图
+//
+// 备注：
+//   - 节点按 aid 去重, 达到 MaxNodes 后停止
+//   - 设置 Checkpoint 后每展开一个节点即保存进度, 再次运行时从断点继续
+//   - 风控/限流 (包括 resty 限流器拒绝) 按 Retries 次指数退避重试
+type RelatedCrawler struct {
+	Seeds      []string      // 种子视频 bvid
+	MaxNodes   int           // 节点上限, 0 表示不限制
+	MaxDepth   int           // 最大深度, 0 表示不限制
+	Interval   time.Duration // 两次请求的最小间隔, 默认为1秒
+	Retries    int           // 单次请求最多尝试次数, 默认为3
+	RetryWait  time.Duration // 首次重试前的等待时间, 默认为30秒
+	Checkpoint string        // 断点文件路径 (可选)
+
+	OnNode func(node RelatedNode) // 发现新节点时回调 (可选)
+
+	info     func(bvid string) (*VideoData, error)
+	related  func(aid int) ([]Related, error)
+	throttle *misc.Throttle
+	state    relatedState
+}
+
+// 断点内容
+type relatedState struct {
+	Nodes    []RelatedNode     `json:"nodes"`
+	Edges    []RelatedEdge     `json:"edges"`
+	Frontier []relatedFrontier `json:"frontier"` // 待展开的节点
+	index    map[int]int       // aid -> Nodes 下标
+}
+
+type relatedFrontier struct {
+	Aid   int `json:"aid"`
+	Depth int `json:"depth"`
+}
+
+// 创建推荐图爬虫
+//
+// Parameters:
+//   - seeds ([]string): 种子视频 bvid
+//   - maxNodes (int): 节点上限, 0 表示不限制
+func (v *Video) NewRelatedCrawler(seeds []string, maxNodes int) *RelatedCrawler {
+	return &RelatedCrawler{
+		Seeds:     seeds,
+		MaxNodes:  maxNodes,
+		Interval:  time.Second,
+		Retries:   3,
+		RetryWait: 30 * time.Second,
+		info: func(bvid string) (*VideoData, error) {
+			resp, err := v.Info(0, bvid)
+			if err != nil {
+				return nil, err
+			}
+			if resp.Code != 0 {
+				return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+			}
+			return &resp.Data, nil
+		},
+		related: func(aid int) ([]Related, error) {
+			resp, err := v.GetRelatedVideos(aid, "")
+			if err != nil {
+				return nil, err
+			}
+			if resp.Code != 0 {
+				return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+			}
+			return resp.Data, nil
+		},
+	}
+}
+
+// Run 开始遍历, 返回当前的推荐图
+//
+// 备注：
+//   - 出错或 ctx 结束时同样返回已构建的部分, 断点已保存
+func (c *RelatedCrawler) Run(ctx context.Context) (*RelatedGraph, error) {
+	c.throttle = &misc.Throttle{Interval: c.Interval}
+
+	resumed, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	if !resumed {
+		if err := c.addSeeds(ctx); err != nil {
+			return c.graph(), err
+		}
+		if err := c.save(); err != nil {
+			return c.graph(), err
+		}
+	}
+
+	for len(c.state.Frontier) > 0 && !c.full() {
+		item := c.state.Frontier[0]
+
+		var list []Related
+		err := c.retry(ctx, func() (err error) {
+			list, err = c.related(item.Aid)
+			return err
+		})
+		if err != nil {
+			return c.graph(), fmt.Errorf("expand av%d: %w", item.Aid, err)
+		}
+
+		for rank, r := range list {
+			if _, ok := c.state.index[r.Aid]; !ok {
+				if c.full() {
+					continue
+				}
+				c.addNode(relatedNode(r, item.Depth+1))
+				if c.MaxDepth <= 0 || item.Depth+1 < c.MaxDepth {
+					c.state.Frontier = append(c.state.Frontier, relatedFrontier{Aid: r.Aid, Depth: item.Depth + 1})
+				}
+			}
+			c.state.Edges = append(c.state.Edges, RelatedEdge{From: item.Aid, To: r.Aid, Rank: rank})
+		}
+
+		c.state.Frontier = c.state.Frontier[1:]
+		if err := c.save(); err != nil {
+			return c.graph(), err
+		}
+	}
+	return c.graph(), nil
+}
+
+func (c *RelatedCrawler) addSeeds(ctx context.Context) error {
+	for _, bvid := range c.Seeds {
+		var data *VideoData
+		err := c.retry(ctx, func() (err error) {
+			data, err = c.info(bvid)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("get seed %s: %w", bvid, err)
+		}
+		if _, ok := c.state.index[data.AID]; ok {
+			continue
+		}
+		c.addNode(RelatedNode{
+			Aid:       data.AID,
+			Bvid:      data.BVID,
+			Title:     data.Title,
+			Tname:     data.TName,
+			OwnerMid:  data.Owner.MID,
+			OwnerName: data.Owner.Name,
+			View:      data.Stat.View,
+			Duration:  data.Duration,
+			Pubdate:   data.PubDate,
+		})
+		c.state.Frontier = append(c.state.Frontier, relatedFrontier{Aid: data.AID})
+	}
+	return nil
+}
+
+func relatedNode(r Related, depth int) RelatedNode {
+	return RelatedNode{
+		Aid:       r.Aid,
+		Bvid:      r.Bvid,
+		Title:     r.Title,
+		Tname:     r.Tname,
+		OwnerMid:  r.Owner.MID,
+		OwnerName: r.Owner.Name,
+		View:      r.Stat.View,
+		Duration:  r.Duration,
+		Pubdate:   r.Pubdate,
+		Depth:     depth,
+	}
+}
+
+func (c *RelatedCrawler) addNode(n RelatedNode) {
+	c.state.index[n.Aid] = len(c.state.Nodes)
+	c.state.Nodes = append(c.state.Nodes, n)
+	if c.OnNode != nil {
+		c.OnNode(n)
+	}
+}
+
+func (c *RelatedCrawler) full() bool {
+	return c.MaxNodes > 0 && len(c.state.Nodes) >= c.MaxNodes
+}
+
+func (c *RelatedCrawler) graph() *RelatedGraph {
+	return &RelatedGraph{
+		Nodes: append([]RelatedNode(nil), c.state.Nodes...),
+		Edges: append([]RelatedEdge(nil), c.state.Edges...),
+	}
+}
+
+func (c *RelatedCrawler) retry(ctx context.Context, fn func() error) error {
+	return misc.Retry(ctx, c.Retries, c.RetryWait, func() error {
+		if err := c.throttle.Wait(ctx); err != nil {
+			return err
+		}
+		return fn()
+	})
+}
+
+// 读取断点, 文件不存在时返回 false
+func (c *RelatedCrawler) load() (bool, error) {
+	c.state = relatedState{index: map[int]int{}}
+	if c.Checkpoint == "" {
+		return false, nil
+	}
+	b, err := os.ReadFile(c.Checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &c.state); err != nil {
+		return false, fmt.Errorf("invalid checkpoint %s: %w", c.Checkpoint, err)
+	}
+	c.state.index = map[int]int{}
+	for i, n := range c.state.Nodes {
+		c.state.index[n.Aid] = i
+	}
+	return true, nil
+}
+
+// 写入断点, 先写临时文件再替换, 避免中断时损坏
+func (c *RelatedCrawler) save() error {
+	if c.Checkpoint == "" {
+		return nil
+	}
+	b, err := json.Marshal(&c.state)
+	if err != nil {
+		return err
+	}
+	tmp := c.Checkpoint + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.Checkpoint)
+}
+
+// WriteGraphML 导出为 GraphML
+func (g *RelatedGraph) WriteGraphML(w io.Writer) error {
+	type key struct {
+		ID   string
+		For  string
+		Name string
+		Type string
+	}
+	keys := []key{
+		{"bvid", "node", "bvid", "string"},
+		{"title", "node", "title", "string"},
+		{"tname", "node", "tname", "string"},
+		{"owner_mid", "node", "owner_mid", "long"},
+		{"owner_name", "node", "owner_name", "string"},
+		{"view", "node", "view", "long"},
+		{"duration", "node", "duration", "int"},
+		{"pubdate", "node", "pubdate", "long"},
+		{"depth", "node", "depth", "int"},
+		{"rank", "edge", "rank", "int"},
+	}
+
+	bw := &errWriter{w: w}
+	bw.printf("%s", xml.Header)
+	bw.printf("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	for _, k := range keys {
+		bw.printf("  <key id=\"%s\" for=\"%s\" attr.name=\"%s\" attr.type=\"%s\"/>\n", k.ID, k.For, k.Name, k.Type)
+	}
+	bw.printf("  <graph id=\"related\" edgedefault=\"directed\">\n")
+	for _, n := range g.Nodes {
+		bw.printf("    <node id=\"av%d\">\n", n.Aid)
+		for _, d := range [][2]string{
+			{"bvid", n.Bvid},
+			{"title", n.Title},
+			{"tname", n.Tname},
+			{"owner_mid", strconv.Itoa(n.OwnerMid)},
+			{"owner_name", n.OwnerName},
+			{"view", strconv.Itoa(n.View)},
+			{"duration", strconv.Itoa(n.Duration)},
+			{"pubdate", strconv.Itoa(n.Pubdate)},
+			{"depth", strconv.Itoa(n.Depth)},
+		} {
+			bw.printf("      <data key=\"%s\">%s</data>\n", d[0], xmlEscape(d[1]))
+		}
+		bw.printf("    </node>\n")
+	}
+	for i, e := range g.Edges {
+		bw.printf("    <edge id=\"e%d\" source=\"av%d\" target=\"av%d\"><data key=\"rank\">%d</data></edge>\n", i, e.From, e.To, e.Rank)
+	}
+	bw.printf("  </graph>\n</graphml>\n")
+	return bw.err
+}
+
+// WriteCSV 导出为节点表与边表两个 CSV
+func (g *RelatedGraph) WriteCSV(nodes, edges io.Writer) error {
+	nw := csv.NewWriter(nodes)
+	nw.Write([]string{"aid", "bvid", "title", "tname", "owner_mid", "owner_name", "view", "duration", "pubdate", "depth"})
+	for _, n := range g.Nodes {
+		nw.Write([]string{
+			strconv.Itoa(n.Aid), n.Bvid, n.Title, n.Tname,
+			strconv.Itoa(n.OwnerMid), n.OwnerName,
+			strconv.Itoa(n.View), strconv.Itoa(n.Duration), strconv.Itoa(n.Pubdate), strconv.Itoa(n.Depth),
+		})
+	}
+	nw.Flush()
+	if err := nw.Error(); err != nil {
+		return err
+	}
+
+	ew := csv.NewWriter(edges)
+	ew.Write([]string{"from", "to", "rank"})
+	for _, e := range g.Edges {
+		ew.Write([]string{strconv.Itoa(e.From), strconv.Itoa(e.To), strconv.Itoa(e.Rank)})
+	}
+	ew.Flush()
+	return ew.Error()
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// 记录第一个写入错误
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
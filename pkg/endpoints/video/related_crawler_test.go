@@ -0,0 +1,80 @@
+package video
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 推荐关系: 1 -> 2, 3; 2 -> 1, 4; 3 -> 4, 5; 4 -> 6
+var relatedFixture = map[int][]int{1: {2, 3}, 2: {1, 4}, 3: {4, 5}, 4: {6}}
+
+func newTestRelatedCrawler(checkpoint string, failAt int) (*RelatedCrawler, *int) {
+	calls := 0
+	c := &RelatedCrawler{
+		Seeds:      []string{"BV1"},
+		Checkpoint: checkpoint,
+		Retries:    1,
+		info: func(bvid string) (*VideoData, error) {
+			return &VideoData{AID: 1, BVID: bvid, Title: "种子"}, nil
+		},
+		related: func(aid int) ([]Related, error) {
+			calls++
+			if calls == failAt {
+				return nil, errors.New("banned")
+			}
+			var list []Related
+			for _, to := range relatedFixture[aid] {
+				list = append(list, Related{Aid: to, Title: "视频 & <" + string(rune('0'+to)) + ">"})
+			}
+			return list, nil
+		},
+	}
+	return c, &calls
+}
+
+func TestRelatedCrawler(t *testing.T) {
+	c, _ := newTestRelatedCrawler("", 0)
+	c.MaxNodes = 5
+	g, err := c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, g.Nodes, 5)
+	assert.Equal(t, []int{0, 1, 1, 2, 2}, []int{g.Nodes[0].Depth, g.Nodes[1].Depth, g.Nodes[2].Depth, g.Nodes[3].Depth, g.Nodes[4].Depth})
+	// 2 -> 1 指向已知节点, 只记边不重复加点
+	assert.Contains(t, g.Edges, RelatedEdge{From: 2, To: 1, Rank: 0})
+
+	c, _ = newTestRelatedCrawler("", 0)
+	c.MaxDepth = 1
+	g, err = c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, g.Nodes, 3)
+
+	var gml bytes.Buffer
+	assert.NoError(t, g.WriteGraphML(&gml))
+	assert.Contains(t, gml.String(), `<edge id="e0" source="av1" target="av2"><data key="rank">0</data></edge>`)
+	assert.Contains(t, gml.String(), `视频 &amp; &lt;2&gt;`)
+
+	var nodes, edges bytes.Buffer
+	assert.NoError(t, g.WriteCSV(&nodes, &edges))
+	assert.Equal(t, 4, strings.Count(nodes.String(), "\n"))
+	assert.Equal(t, "from,to,rank\n1,2,0\n1,3,1\n", edges.String())
+}
+
+func TestRelatedCrawlerResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frontier.json")
+
+	c, _ := newTestRelatedCrawler(path, 3)
+	_, err := c.Run(context.Background())
+	assert.Error(t, err)
+
+	c, calls := newTestRelatedCrawler(path, 0)
+	g, err := c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, g.Nodes, 6)
+	assert.Equal(t, 4, *calls) // 已展开的 1, 2 不再请求, 继续展开 3, 4, 5, 6
+}
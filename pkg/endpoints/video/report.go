@@ -1,3 +1,319 @@
 package video
 
-// todo
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 心跳播放动作
+const (
+	PlayTypePlaying = 0 // 播放中
+	PlayTypeStart   = 1 // 开始播放
+	PlayTypePause   = 2 // 暂停
+	PlayTypeResume  = 3 // 继续播放
+)
+
+// 上报观看进度
+//
+// Parameters:
+//   - aid (int): 视频的 aid
+//   - cid (int): 视频的 cid
+//   - progress (int): 观看进度, 单位为秒
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//   - 需要 CSRF Token
+func (v *Video) ReportHistory(aid int, cid int, progress int) (*ReportResponse, error) {
+	baseURL := "https://api.bilibili.com/x/v2/history/report"
+
+	formData := map[string]string{
+		"aid":      fmt.Sprintf("%d", aid),
+		"cid":      fmt.Sprintf("%d", cid),
+		"progress": fmt.Sprintf("%d", progress),
+		"platform": "web",
+		"csrf":     v.client.CSRF,
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&ReportResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("request failed with status: %s", resp.Status())
+	}
+	return resp.Result().(*ReportResponse), nil
+}
+
+// 上报播放心跳
+//
+// Parameters:
+//   - aid (int): 视频的 aid, 与 bvid 任选一个
+//   - bvid (string): 视频的 bvid, 与 aid 任选一个
+//   - cid (int): 视频的 cid
+//   - playedTime (int): 当前播放进度, 单位为秒, 播放完毕为 -1
+//   - realPlayedTime (int): 本次实际播放时长, 单位为秒
+//   - startTs (int): 开始播放时的 unix 时间戳
+//   - playType (int): 播放动作, 见 PlayTypePlaying 等
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//   - 需要 CSRF Token
+func (v *Video) Heartbeat(aid int, bvid string, cid int, playedTime, realPlayedTime int, startTs int, playType int) (*ReportResponse, error) {
+	baseURL := "https://api.bilibili.com/x/click-interface/web/heartbeat"
+
+	formData := map[string]string{
+		"aid":              fmt.Sprintf("%d", aid),
+		"bvid":             bvid,
+		"cid":              fmt.Sprintf("%d", cid),
+		"mid":              fmt.Sprintf("%d", v.client.DedeUserID),
+		"played_time":      fmt.Sprintf("%d", playedTime),
+		"real_played_time": fmt.Sprintf("%d", realPlayedTime),
+		"realtime":         fmt.Sprintf("%d", realPlayedTime),
+		"start_ts":         fmt.Sprintf("%d", startTs),
+		"play_type":        fmt.Sprintf("%d", playType),
+		"type":             "3",
+		"dt":               "2",
+		"csrf":             v.client.CSRF,
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&ReportResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("request failed with status: %s", resp.Status())
+	}
+	return resp.Result().(*ReportResponse), nil
+}
+
+// ReportResponse 上报进度/心跳
+type ReportResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -111表示csrf校验失败, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+}
+
+// PlaybackSession 一次播放会话, 定时及在暂停/跳转/停止时上报心跳
+//
+// 备注：
+//   - 播放进度由调用方在各操作时传入, 两次操作之间按实际经过时间推算
+//   - 停止时额外上报观看历史, 使 StreamData 的 LastPlayTime/LastPlayCid 保持同步
+type PlaybackSession struct {
+	Aid      int
+	Bvid     string
+	Cid      int
+	Interval time.Duration   // 播放中的心跳间隔, 默认为15秒, 不大于0时同默认值
+	OnError  func(err error) // 定时心跳出错回调 (可选)
+
+	heartbeat func(playedTime, realPlayedTime, startTs, playType int) error
+	report    func(progress int) error
+	now       func() time.Time
+
+	mu         sync.Mutex
+	startTs    int64
+	position   float64   // 上次操作时的进度, 单位为秒
+	updatedAt  time.Time // 上次操作的时间
+	realPlayed time.Duration
+	playing    bool
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// 创建播放会话
+//
+// Parameters:
+//   - aid (int): 视频的 aid
+//   - bvid (string): 视频的 bvid (可选)
+//   - cid (int): 视频的 cid
+func (v *Video) NewPlaybackSession(aid int, bvid string, cid int) *PlaybackSession {
+	s := &PlaybackSession{
+		Aid:      aid,
+		Bvid:     bvid,
+		Cid:      cid,
+		Interval: 15 * time.Second,
+		now:      time.Now,
+	}
+	s.heartbeat = func(playedTime, realPlayedTime, startTs, playType int) error {
+		resp, err := v.Heartbeat(aid, bvid, cid, playedTime, realPlayedTime, startTs, playType)
+		if err != nil {
+			return err
+		}
+		if resp.Code != 0 {
+			return &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return nil
+	}
+	s.report = func(progress int) error {
+		resp, err := v.ReportHistory(aid, cid, progress)
+		if err != nil {
+			return err
+		}
+		if resp.Code != 0 {
+			return &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return nil
+	}
+	return s
+}
+
+// Start 开始播放并启动定时心跳
+//
+// Parameters:
+//   - position (int): 起始进度, 单位为秒, 可传入 LastPlayTime/1000 续播
+func (s *PlaybackSession) Start(position int) error {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("playback session already started")
+	}
+	now := s.now()
+	s.startTs = now.Unix()
+	s.position = float64(position)
+	s.updatedAt = now
+	s.playing = true
+	stop, done := make(chan struct{}), make(chan struct{})
+	s.stop, s.done = stop, done
+	send := s.beat(PlayTypeStart)
+	s.mu.Unlock()
+
+	go s.loop(stop, done)
+	return send()
+}
+
+// Pause 暂停播放
+func (s *PlaybackSession) Pause(position int) error {
+	s.mu.Lock()
+	s.advance(float64(position))
+	s.playing = false
+	send := s.beat(PlayTypePause)
+	s.mu.Unlock()
+	return send()
+}
+
+// Resume 继续播放
+func (s *PlaybackSession) Resume(position int) error {
+	s.mu.Lock()
+	s.advance(float64(position))
+	s.playing = true
+	send := s.beat(PlayTypeResume)
+	s.mu.Unlock()
+	return send()
+}
+
+// Seek 跳转进度
+func (s *PlaybackSession) Seek(position int) error {
+	s.mu.Lock()
+	s.advance(float64(position))
+	send := s.beat(PlayTypePlaying)
+	s.mu.Unlock()
+	return send()
+}
+
+// Stop 结束播放, 停止定时心跳并上报观看历史
+//
+// Parameters:
+//   - position (int): 结束进度, 单位为秒, 播放完毕传 -1
+func (s *PlaybackSession) Stop(position int) error {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	s.mu.Lock()
+	s.advance(float64(position))
+	s.playing = false
+	// 接口没有单独的结束动作, 以最终进度补发一次心跳
+	send := s.beat(PlayTypePlaying)
+	s.mu.Unlock()
+	if err := send(); err != nil {
+		return err
+	}
+	return s.report(position)
+}
+
+// Position 返回当前推算的播放进度, 单位为秒
+func (s *PlaybackSession) Position() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current()
+}
+
+func (s *PlaybackSession) loop(stop, done chan struct{}) {
+	defer close(done)
+	interval := s.Interval
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if !s.playing {
+				s.mu.Unlock()
+				continue
+			}
+			s.advance(s.current())
+			send := s.beat(PlayTypePlaying)
+			s.mu.Unlock()
+			if err := send(); err != nil && s.OnError != nil {
+				s.OnError(err)
+			}
+		}
+	}
+}
+
+// 推算当前进度, 需持有锁
+func (s *PlaybackSession) current() float64 {
+	if !s.playing {
+		return s.position
+	}
+	return s.position + s.now().Sub(s.updatedAt).Seconds()
+}
+
+// 记录实际播放时长并更新进度, 需持有锁
+func (s *PlaybackSession) advance(position float64) {
+	now := s.now()
+	if s.playing {
+		s.realPlayed += now.Sub(s.updatedAt)
+	}
+	s.position = position
+	s.updatedAt = now
+}
+
+// 记录当前的心跳参数, 需持有锁; 返回的函数在释放锁后调用, 避免请求期间阻塞其他操作
+func (s *PlaybackSession) beat(playType int) func() error {
+	played, realPlayed, startTs := int(s.position), int(s.realPlayed.Seconds()), int(s.startTs)
+	return func() error {
+		return s.heartbeat(played, realPlayed, startTs, playType)
+	}
+}
@@ -0,0 +1,90 @@
+package video
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaybackSession(t *testing.T) {
+	clock := time.Unix(1700000000, 0)
+	type beat struct{ played, real, playType int }
+	var beats []beat
+	var reported []int
+
+	s := &PlaybackSession{
+		Interval: time.Hour,
+		now:      func() time.Time { return clock },
+		heartbeat: func(playedTime, realPlayedTime, startTs, playType int) error {
+			assert.Equal(t, 1700000000, startTs)
+			beats = append(beats, beat{playedTime, realPlayedTime, playType})
+			return nil
+		},
+		report: func(progress int) error {
+			reported = append(reported, progress)
+			return nil
+		},
+	}
+
+	assert.NoError(t, s.Start(30))
+	assert.Error(t, s.Start(0))
+
+	clock = clock.Add(10 * time.Second)
+	assert.InDelta(t, 40, s.Position(), 1e-9)
+	assert.NoError(t, s.Pause(40))
+
+	clock = clock.Add(time.Minute) // 暂停期间不计入实际播放时长
+	assert.NoError(t, s.Resume(40))
+	clock = clock.Add(5 * time.Second)
+	assert.NoError(t, s.Seek(100))
+	clock = clock.Add(5 * time.Second)
+	assert.NoError(t, s.Stop(105))
+
+	assert.Equal(t, []beat{
+		{30, 0, PlayTypeStart},
+		{40, 10, PlayTypePause},
+		{40, 10, PlayTypeResume},
+		{100, 15, PlayTypePlaying},
+		{105, 20, PlayTypePlaying},
+	}, beats)
+	assert.Equal(t, []int{105}, reported)
+}
+
+func TestPlaybackSessionZeroInterval(t *testing.T) {
+	s := &PlaybackSession{
+		now:       time.Now,
+		heartbeat: func(playedTime, realPlayedTime, startTs, playType int) error { return nil },
+		report:    func(progress int) error { return nil },
+	}
+	assert.NoError(t, s.Start(0))
+	assert.NoError(t, s.Stop(1))
+}
+
+func TestPlaybackSessionHeartbeatUnlocked(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	s := &PlaybackSession{
+		Interval: time.Hour,
+		now:      time.Now,
+		heartbeat: func(playedTime, realPlayedTime, startTs, playType int) error {
+			if playType == PlayTypePause {
+				close(entered)
+				<-release
+			}
+			return nil
+		},
+		report: func(progress int) error { return nil },
+	}
+	assert.NoError(t, s.Start(0))
+
+	paused := make(chan error)
+	go func() { paused <- s.Pause(10) }()
+	<-entered
+
+	// 心跳请求未返回时仍可读取进度与跳转
+	assert.InDelta(t, 10, s.Position(), 1e-9)
+	assert.NoError(t, s.Seek(20))
+	close(release)
+	assert.NoError(t, <-paused)
+	assert.NoError(t, s.Stop(20))
+}
@@ -1,3 +1,245 @@
 package video
 
-// todo
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 获取视频快照 (雪碧图)
+//
+// Parameters:
+//   - aid (int): 视频的aid, 与 bvid 任选一个
+//   - bvid (string): 视频的bvid, 与 aid 任选一个
+//   - cid (int): 视频的cid (可选, 默认为1P)
+//   - index (int): 是否在 data.index 中返回时间轴, 0: 否, 1: 是
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+func (v *Video) Snapshot(aid int, bvid string, cid int, index int) (*SnapshotResponse, error) {
+	baseURL := "https://api.bilibili.com/x/player/videoshot"
+
+	formData := map[string]string{
+		"aid":   fmt.Sprintf("%d", aid),
+		"bvid":  bvid,
+		"index": fmt.Sprintf("%d", index),
+	}
+	if cid != 0 {
+		formData["cid"] = fmt.Sprintf("%d", cid)
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&SnapshotResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*SnapshotResponse), nil
+}
+
+// 获取快照并下载时间轴, 返回可按时间取帧的 Thumbnails
+//
+// Parameters:
+//   - aid (int): 视频的aid, 与 bvid 任选一个
+//   - bvid (string): 视频的bvid, 与 aid 任选一个
+//   - cid (int): 视频的cid (可选, 默认为1P)
+//
+// 备注：
+//   - 雪碧图在首次取帧时下载并缓存
+func (v *Video) LoadThumbnails(aid int, bvid string, cid int) (*Thumbnails, error) {
+	resp, err := v.Snapshot(aid, bvid, cid, 0)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+	}
+	return v.NewThumbnails(&resp.Data)
+}
+
+// 由快照数据创建 Thumbnails, 并下载解析 pvdata 时间轴
+func (v *Video) NewThumbnails(data *SnapshotData) (*Thumbnails, error) {
+	if len(data.Image) == 0 || data.ImgXLen <= 0 || data.ImgYLen <= 0 {
+		return nil, fmt.Errorf("videoshot has no images")
+	}
+
+	t := &Thumbnails{video: v, data: *data, sheets: map[int]image.Image{}}
+	if len(data.Index) > 0 {
+		t.timestamps = data.Index
+		return t, nil
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("Referer", "https://www.bilibili.com").
+		Get(absURL(data.PvData))
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("request pvdata failed with status: %s", resp.Status())
+	}
+	t.timestamps = ParsePvData(resp.Body())
+	return t, nil
+}
+
+// 解析 pvdata 二进制时间轴
+//
+// 备注：
+//   - 数据为大端序 uint16 数组, 单位为秒
+//   - 第一个值为占位, 不对应任何帧
+func ParsePvData(b []byte) []int {
+	n := len(b) / 2
+	if n <= 1 {
+		return nil
+	}
+	timestamps := make([]int, 0, n-1)
+	for i := 1; i < n; i++ {
+		timestamps = append(timestamps, int(binary.BigEndian.Uint16(b[i*2:])))
+	}
+	return timestamps
+}
+
+// Thumbnails 视频快照帧索引
+type Thumbnails struct {
+	video      *Video
+	data       SnapshotData
+	timestamps []int
+
+	mu     sync.Mutex
+	sheets map[int]image.Image
+}
+
+// Timestamps 返回每帧对应的时间, 单位为秒
+func (t *Thumbnails) Timestamps() []int {
+	return t.timestamps
+}
+
+// FrameIndex 返回时间对应的帧序号, 取不晚于该时间的最后一帧
+func (t *Thumbnails) FrameIndex(second int) int {
+	i := sort.Search(len(t.timestamps), func(i int) bool {
+		return t.timestamps[i] > second
+	}) - 1
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// Frame 返回指定时间的缩略图
+//
+// Parameters:
+//   - second (int): 视频时间, 单位为秒
+func (t *Thumbnails) Frame(second int) (image.Image, error) {
+	return t.FrameAt(t.FrameIndex(second))
+}
+
+// FrameAt 返回指定帧序号的缩略图
+func (t *Thumbnails) FrameAt(index int) (image.Image, error) {
+	perSheet := t.data.ImgXLen * t.data.ImgYLen
+	sheetIndex := index / perSheet
+	if index < 0 || sheetIndex >= len(t.data.Image) {
+		return nil, fmt.Errorf("frame %d out of range", index)
+	}
+
+	sheet, err := t.sheet(sheetIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// 帧尺寸以接口返回为准, 缺失时按雪碧图均分
+	w, h := t.data.ImgXSize, t.data.ImgYSize
+	bounds := sheet.Bounds()
+	if w <= 0 {
+		w = bounds.Dx() / t.data.ImgXLen
+	}
+	if h <= 0 {
+		h = bounds.Dy() / t.data.ImgYLen
+	}
+
+	pos := index % perSheet
+	x := bounds.Min.X + pos%t.data.ImgXLen*w
+	y := bounds.Min.Y + pos/t.data.ImgXLen*h
+	rect := image.Rect(x, y, x+w, y+h).Intersect(bounds)
+	if rect.Empty() {
+		return nil, fmt.Errorf("frame %d is outside of sprite sheet", index)
+	}
+
+	if sub, ok := sheet.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(rect), nil
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), sheet, rect.Min, draw.Src)
+	return dst, nil
+}
+
+// 下载并缓存雪碧图
+func (t *Thumbnails) sheet(index int) (image.Image, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if img, ok := t.sheets[index]; ok {
+		return img, nil
+	}
+
+	resp, err := t.video.client.HTTPClient.R().
+		SetHeader("Referer", "https://www.bilibili.com").
+		Get(absURL(t.data.Image[index]))
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("request sprite sheet failed with status: %s", resp.Status())
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sprite sheet: %w", err)
+	}
+	t.sheets[index] = img
+	return img, nil
+}
+
+// 资源地址常为 //i0.hdslb.com/... 形式, 补全协议
+func absURL(url string) string {
+	if strings.HasPrefix(url, "//") {
+		return "https:" + url
+	}
+	return url
+}
+
+// SnapshotResponse 视频快照
+type SnapshotResponse struct {
+	Code    int          `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -404表示不存在该稿件
+	Message string       `json:"message"` // 错误信息, 默认为0
+	TTL     int          `json:"ttl"`     // TTL, 固定值1
+	Data    SnapshotData `json:"data"`    // 数据本体
+}
+
+// SnapshotData 视频快照数据
+type SnapshotData struct {
+	PvData   string   `json:"pvdata"`     // bin 格式时间轴地址, 大端序 uint16 数组
+	ImgXLen  int      `json:"img_x_len"`  // 每行图片数, 一般为10
+	ImgYLen  int      `json:"img_y_len"`  // 每列图片数, 一般为10
+	ImgXSize int      `json:"img_x_size"` // 每张图片宽度, 一般为160
+	ImgYSize int      `json:"img_y_size"` // 每张图片高度, 一般为90
+	Image    []string `json:"image"`      // 雪碧图 url 列表
+	Index    []int    `json:"index"`      // 时间轴, 仅 index=1 时存在, 单位为秒
+}
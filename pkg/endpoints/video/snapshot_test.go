@@ -0,0 +1,91 @@
+package video
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Yuelioi/bilibili/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePvData(t *testing.T) {
+	var buf bytes.Buffer
+	for _, v := range []uint16{0, 0, 5, 10, 300} {
+		binary.Write(&buf, binary.BigEndian, v)
+	}
+	assert.Equal(t, []int{0, 5, 10, 300}, ParsePvData(buf.Bytes()))
+	assert.Nil(t, ParsePvData([]byte{0, 0}))
+}
+
+func TestThumbnailsFrame(t *testing.T) {
+	// 2x2 的雪碧图, 每帧 4x3, 每帧填充不同颜色
+	colors := []color.RGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}, {255, 255, 0, 255}}
+	sheet := image.NewRGBA(image.Rect(0, 0, 8, 6))
+	for i, c := range colors {
+		x0, y0 := i%2*4, i/2*3
+		for y := y0; y < y0+3; y++ {
+			for x := x0; x < x0+4; x++ {
+				sheet.Set(x, y, c)
+			}
+		}
+	}
+	var sheetPNG bytes.Buffer
+	assert.NoError(t, png.Encode(&sheetPNG, sheet))
+
+	var pv bytes.Buffer
+	for _, v := range []uint16{0, 0, 10, 20, 30, 40} {
+		binary.Write(&pv, binary.BigEndian, v)
+	}
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pv.bin":
+			w.Write(pv.Bytes())
+		case "/sheet0.png", "/sheet1.png":
+			requests++
+			w.Write(sheetPNG.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	service := New(client.New())
+	thumbs, err := service.NewThumbnails(&SnapshotData{
+		PvData:   srv.URL + "/pv.bin",
+		ImgXLen:  2,
+		ImgYLen:  2,
+		ImgXSize: 4,
+		ImgYSize: 3,
+		Image:    []string{srv.URL + "/sheet0.png", srv.URL + "/sheet1.png"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 10, 20, 30, 40}, thumbs.Timestamps())
+
+	frame, err := thumbs.Frame(25)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, frame.Bounds().Dx())
+	assert.Equal(t, 3, frame.Bounds().Dy())
+	r, g, b, _ := frame.At(frame.Bounds().Min.X, frame.Bounds().Min.Y).RGBA()
+	assert.Equal(t, []uint32{0, 0, 0xFFFF}, []uint32{r, g, b})
+
+	// 第 5 帧位于第二张雪碧图
+	frame, err = thumbs.Frame(45)
+	assert.NoError(t, err)
+	r, g, b, _ = frame.At(frame.Bounds().Min.X, frame.Bounds().Min.Y).RGBA()
+	assert.Equal(t, []uint32{0xFFFF, 0, 0}, []uint32{r, g, b})
+
+	_, err = thumbs.Frame(5)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests) // 雪碧图只下载一次
+
+	_, err = thumbs.FrameAt(8)
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package video
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 投稿排序方式
+const (
+	SpaceOrderPubdate = "pubdate" // 最新发布
+	SpaceOrderClick   = "click"   // 最多播放
+	SpaceOrderStow    = "stow"    // 最多收藏
+)
+
+// 查询用户投稿视频明细
+//
+// Parameters:
+//   - mid (int): 目标用户 mid
+//   - keyword (string): 搜索关键词 (可选)
+//   - tid (int): 分区 tid, 0 为不筛选
+//   - order (string): 排序方式, 见 SpaceOrderPubdate 等, 默认为 pubdate
+//   - pn (int): 页码, 默认为1
+//   - ps (int): 每页项数, 默认为30, 最大为50
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//   - 鉴权方式：Wbi 签名
+//
+// 备注：
+//   - 请求过快会返回 -352 或 -799
+func (v *Video) SpaceArchives(mid int, keyword string, tid int, order string, pn, ps int) (*SpaceArchivesResponse, error) {
+	baseURL := "https://api.bilibili.com/x/space/wbi/arc/search"
+
+	if pn == 0 {
+		pn = 1
+	}
+	if ps == 0 {
+		ps = 30
+	}
+	if order == "" {
+		order = SpaceOrderPubdate
+	}
+
+	params := url.Values{}
+	params.Set("mid", fmt.Sprintf("%d", mid))
+	params.Set("keyword", keyword)
+	params.Set("tid", fmt.Sprintf("%d", tid))
+	params.Set("order", order)
+	params.Set("pn", fmt.Sprintf("%d", pn))
+	params.Set("ps", fmt.Sprintf("%d", ps))
+	params.Set("platform", "web")
+	params.Set("web_location", "1550101")
+	// 浏览器环境指纹, 缺失时容易触发 -352
+	params.Set("dm_img_list", "[]")
+	params.Set("dm_img_str", "V2ViR0wgMS4wIChPcGVuR0wgRVMgMi4wIENocm9taXVtKQ")
+	params.Set("dm_cover_img_str", "QU5HTEUgKE5WSURJQSwgTlZJRElBIEdlRm9yY2UgR1RYIDEwNjAgNkdCIERpcmVjdDNEMTEgdnNfNV8wIHBzXzVfMCksIG9yIHNpbWlsYXI")
+
+	newUrl, err := login.New(v.client).SignAndGenerateURL(baseURL + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("User-Agent", v.client.UserAgent).
+		SetHeader("Referer", fmt.Sprintf("https://space.bilibili.com/%d/video", mid)).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&SpaceArchivesResponse{}).
+		Get(newUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*SpaceArchivesResponse), nil
+}
+
+// 遍历用户的全部投稿视频
+//
+// Parameters:
+//   - ctx (context.Context): 用于取消遍历
+//   - mid (int): 目标用户 mid
+//   - keyword (string): 搜索关键词 (可选)
+//   - tid (int): 分区 tid, 0 为不筛选
+//   - order (string): 排序方式, 见 SpaceOrderPubdate 等
+//   - pageSize (int): 每页项数, 最大为50
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func (v *Video) SpaceArchivesIter(ctx context.Context, mid int, keyword string, tid int, order string, pageSize, maxItems int) *misc.Pager[SpaceArchive] {
+	return misc.NewPager(ctx, pageSize, 50, maxItems, func(pn, ps int) ([]SpaceArchive, int, error) {
+		resp, err := v.SpaceArchives(mid, keyword, tid, order, pn, ps)
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.Code != 0 {
+			return nil, 0, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+		}
+		return resp.Data.List.Vlist, resp.Data.Page.Count, nil
+	})
+}
+
+// SpaceArchivesResponse 用户投稿视频明细
+type SpaceArchivesResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -352表示风控校验失败, -400表示请求错误, -412表示请求被拦截
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		List struct {
+			Tlist map[string]struct {
+				Tid   int    `json:"tid"`   // 分区 tid
+				Count int    `json:"count"` // 该分区投稿数
+				Name  string `json:"name"`  // 分区名称
+			} `json:"tlist"` // 投稿视频分区索引
+			Vlist []SpaceArchive `json:"vlist"` // 投稿视频列表
+			Slist []interface{}  `json:"slist"` // 作用尚不明确
+		} `json:"list"` // 列表信息
+		Page struct {
+			PN    int `json:"pn"`    // 当前页码
+			PS    int `json:"ps"`    // 每页项数
+			Count int `json:"count"` // 总计稿件数
+		} `json:"page"` // 页面信息
+		EpisodicButton struct {
+			Text string `json:"text"` // 按钮文字
+			URI  string `json:"uri"`  // 全部播放页 url
+		} `json:"episodic_button"` // "播放全部"按钮
+		IsRisk      bool        `json:"is_risk"`       // 是否触发风控
+		GaiaResType int         `json:"gaia_res_type"` // 风控类型
+		GaiaData    interface{} `json:"gaia_data"`     // 风控数据
+	} `json:"data"` // 数据本体
+}
+
+// SpaceArchive 用户投稿视频
+type SpaceArchive struct {
+	Aid            int         `json:"aid"`              // 稿件 avid
+	Bvid           string      `json:"bvid"`             // 稿件 bvid
+	Title          string      `json:"title"`            // 视频标题
+	Subtitle       string      `json:"subtitle"`         // 作用尚不明确
+	Description    string      `json:"description"`      // 视频简介
+	Pic            string      `json:"pic"`              // 视频封面
+	Typeid         int         `json:"typeid"`           // 分区 tid
+	Copyright      string      `json:"copyright"`        // 版权标志
+	Author         string      `json:"author"`           // UP 主昵称
+	Mid            int         `json:"mid"`              // UP 主 mid
+	Created        int         `json:"created"`          // 投稿时间
+	Length         string      `json:"length"`           // 视频长度, MM:SS
+	Play           FlexInt     `json:"play"`             // 播放数, 无权限时为 "--"
+	Comment        int         `json:"comment"`          // 评论数
+	VideoReview    int         `json:"video_review"`     // 弹幕数
+	Review         int         `json:"review"`           // 作用尚不明确
+	HideClick      bool        `json:"hide_click"`       // 是否隐藏播放数
+	IsPay          int         `json:"is_pay"`           // 是否为付费视频
+	IsUnionVideo   int         `json:"is_union_video"`   // 是否为合作视频
+	IsSteinsGate   int         `json:"is_steins_gate"`   // 是否为互动视频
+	IsLivePlayback int         `json:"is_live_playback"` // 是否为直播回放
+	IsAvoided      int         `json:"is_avoided"`       // 作用尚不明确
+	Attribute      int         `json:"attribute"`        // 稿件属性位
+	SeasonID       int         `json:"season_id"`        // 所属合集 id
+	Meta           interface{} `json:"meta"`             // 所属合集信息
+	EnableVT       int         `json:"enable_vt"`        // 作用尚不明确
+	VT             int         `json:"vt"`               // 作用尚不明确
+	VTDisplay      string      `json:"vt_display"`       // 作用尚不明确
+}
+
+// FlexInt 兼容数字与非数字字符串 (如 "--") 的整数, 无法解析时为0
+type FlexInt int
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (f *FlexInt) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err == nil {
+		i, _ := strconv.Atoi(n.String())
+		*f = FlexInt(i)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	i, _ := strconv.Atoi(s)
+	*f = FlexInt(i)
+	return nil
+}
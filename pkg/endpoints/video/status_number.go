@@ -1 +1,243 @@
 package video
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+)
+
+// 获取视频状态数
+//
+// Parameters:
+//   - aid (int): 视频的 aid, 与 bvid 任选一个
+//   - bvid (string): 视频的 bvid, 与 aid 任选一个
+//
+// 备注：
+//   - 比 Info 轻量, 仅返回状态数
+func (v *Video) Stat(aid int, bvid string) (*StatResponse, error) {
+	baseURL := "https://api.bilibili.com/x/web-interface/archive/stat"
+
+	formData := map[string]string{
+		"aid":  fmt.Sprintf("%d", aid),
+		"bvid": bvid,
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetResult(&StatResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*StatResponse), nil
+}
+
+// StatResponse 视频状态数
+type StatResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, 40001表示不存在该稿件
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Aid        int    `json:"aid"`        // 稿件 avid
+		Bvid       string `json:"bvid"`       // 稿件 bvid
+		View       int    `json:"view"`       // 播放数
+		Danmaku    int    `json:"danmaku"`    // 弹幕数
+		Reply      int    `json:"reply"`      // 评论数
+		Favorite   int    `json:"favorite"`   // 收藏数
+		Coin       int    `json:"coin"`       // 投币数
+		Share      int    `json:"share"`      // 分享数
+		NowRank    int    `json:"now_rank"`   // 当前排名
+		HisRank    int    `json:"his_rank"`   // 历史最高排行
+		Like       int    `json:"like"`       // 获赞数
+		Dislike    int    `json:"dislike"`    // 点踩数, 恒为0
+		NoReprint  int    `json:"no_reprint"` // 禁止转载标志, 0: 无, 1: 禁止
+		Copyright  int    `json:"copyright"`  // 版权标志, 1: 自制, 2: 转载
+		ArgueMsg   string `json:"argue_msg"`  // 警告信息
+		Evaluation string `json:"evaluation"` // 视频评分
+	} `json:"data"` // 数据本体
+}
+
+// StatTarget 采样目标
+type StatTarget struct {
+	Aid  int    // 稿件 avid, 与 Bvid 任选一个
+	Bvid string // 稿件 bvid, 与 Aid 任选一个
+	Cid  int    // 在线人数所用 cid, 为 0 时不采集在线人数
+}
+
+// StatSample 一次采样结果
+type StatSample struct {
+	Time       time.Time `json:"time"`        // 采样时间
+	Aid        int       `json:"aid"`         // 稿件 avid
+	Bvid       string    `json:"bvid"`        // 稿件 bvid
+	View       int       `json:"view"`        // 播放数
+	Danmaku    int       `json:"danmaku"`     // 弹幕数
+	Reply      int       `json:"reply"`       // 评论数
+	Favorite   int       `json:"favorite"`    // 收藏数
+	Coin       int       `json:"coin"`        // 投币数
+	Share      int       `json:"share"`       // 分享数
+	Like       int       `json:"like"`        // 获赞数
+	Online     int       `json:"online"`      // 所有终端在线人数, 如 "10万+" 记为下限 100000
+	OnlineText string    `json:"online_text"` // 接口返回的原始在线人数
+}
+
+// StatSampler 定时采集一组视频的状态数与在线人数
+type StatSampler struct {
+	Targets         []StatTarget                  // 采样目标
+	Interval        time.Duration                 // 采样周期, 不大于0时为1分钟
+	RequestInterval time.Duration                 // 两次请求的最小间隔, 用于限速, 修改后在下次 Run 时生效
+	MaxSamples      int                           // 每个视频保留的最大样本数, 0 表示不限制
+	OnSample        func(sample StatSample)       // 每次采样后回调 (可选)
+	OnError         func(t StatTarget, err error) // 采样出错回调 (可选), 出错的目标本轮跳过
+
+	stat   func(aid int, bvid string) (*StatResponse, error)
+	online func(aid int, bvid string, cid int) (*OnlineTotalResponse, error)
+
+	throttle *misc.Throttle
+
+	mu     sync.Mutex
+	series map[string][]StatSample
+}
+
+// 创建状态数采样器
+//
+// Parameters:
+//   - targets ([]StatTarget): 采样目标
+//   - interval (time.Duration): 采样周期
+//
+// 备注：
+//   - 默认请求间隔为 1 秒
+func (v *Video) NewStatSampler(targets []StatTarget, interval time.Duration) *StatSampler {
+	return &StatSampler{
+		Targets:         targets,
+		Interval:        interval,
+		RequestInterval: time.Second,
+		stat:            v.Stat,
+		online:          v.OnlineTotal,
+		throttle:        &misc.Throttle{Interval: time.Second},
+		series:          map[string][]StatSample{},
+	}
+}
+
+// Run 按周期采样, 直到 ctx 结束
+func (s *StatSampler) Run(ctx context.Context) error {
+	s.throttle = &misc.Throttle{Interval: s.RequestInterval}
+	interval := s.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SampleOnce(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SampleOnce 对全部目标采样一次, 返回本轮成功的样本
+//
+// 备注：
+//   - 单个目标出错不会中断采样, 仅在 ctx 结束时返回错误
+func (s *StatSampler) SampleOnce(ctx context.Context) ([]StatSample, error) {
+	var samples []StatSample
+	for _, t := range s.Targets {
+		sample, err := s.sample(ctx, t)
+		if ctx.Err() != nil {
+			return samples, ctx.Err()
+		}
+		if err != nil {
+			if s.OnError != nil {
+				s.OnError(t, err)
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		key := seriesKey(t.Aid, t.Bvid)
+		list := append(s.series[key], sample)
+		if s.MaxSamples > 0 && len(list) > s.MaxSamples {
+			list = list[len(list)-s.MaxSamples:]
+		}
+		s.series[key] = list
+		s.mu.Unlock()
+
+		if s.OnSample != nil {
+			s.OnSample(sample)
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// Series 返回某个视频的样本时间序列
+//
+// Parameters:
+//   - aid (int): 与 StatTarget 中一致的 aid
+//   - bvid (string): 与 StatTarget 中一致的 bvid
+func (s *StatSampler) Series(aid int, bvid string) []StatSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]StatSample(nil), s.series[seriesKey(aid, bvid)]...)
+}
+
+func (s *StatSampler) sample(ctx context.Context, t StatTarget) (StatSample, error) {
+	if err := s.throttle.Wait(ctx); err != nil {
+		return StatSample{}, err
+	}
+	resp, err := s.stat(t.Aid, t.Bvid)
+	if err != nil {
+		return StatSample{}, err
+	}
+	if resp.Code != 0 {
+		return StatSample{}, &misc.CodeError{Code: resp.Code, Message: resp.Message}
+	}
+
+	d := resp.Data
+	sample := StatSample{
+		Time:     time.Now(),
+		Aid:      d.Aid,
+		Bvid:     d.Bvid,
+		View:     d.View,
+		Danmaku:  d.Danmaku,
+		Reply:    d.Reply,
+		Favorite: d.Favorite,
+		Coin:     d.Coin,
+		Share:    d.Share,
+		Like:     d.Like,
+	}
+	if t.Cid == 0 {
+		return sample, nil
+	}
+
+	if err := s.throttle.Wait(ctx); err != nil {
+		return StatSample{}, err
+	}
+	online, err := s.online(t.Aid, t.Bvid, t.Cid)
+	if err != nil {
+		return StatSample{}, err
+	}
+	if online.Code != 0 {
+		return StatSample{}, &misc.CodeError{Code: online.Code, Message: online.Message}
+	}
+	sample.OnlineText = online.Data.Total
+	sample.Online = ParseOnlineRange(online.Data.Total).Min
+	return sample, nil
+}
+
+func seriesKey(aid int, bvid string) string {
+	if bvid != "" {
+		return bvid
+	}
+	return strconv.Itoa(aid)
+}
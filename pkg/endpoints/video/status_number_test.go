@@ -0,0 +1,101 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatSampler(t *testing.T) {
+	views := 100
+	s := &StatSampler{
+		Targets:    []StatTarget{{Bvid: "BV1", Cid: 1}, {Bvid: "BV2"}, {Bvid: "BV3"}},
+		MaxSamples: 2,
+		stat: func(aid int, bvid string) (*StatResponse, error) {
+			if bvid == "BV2" {
+				return nil, errors.New("boom")
+			}
+			resp := &StatResponse{}
+			if bvid == "BV3" {
+				resp.Code, resp.Message = -412, "请求被拦截"
+				return resp, nil
+			}
+			resp.Data.Bvid = bvid
+			resp.Data.View = views
+			views += 10
+			return resp, nil
+		},
+		online: func(aid int, bvid string, cid int) (*OnlineTotalResponse, error) {
+			resp := &OnlineTotalResponse{}
+			resp.Data.Total = "1.2万+"
+			return resp, nil
+		},
+		throttle: &misc.Throttle{},
+		series:   map[string][]StatSample{},
+	}
+
+	var failed []StatTarget
+	var lastErr error
+	s.OnError = func(t StatTarget, err error) {
+		failed = append(failed, t)
+		lastErr = err
+	}
+
+	for i := 0; i < 3; i++ {
+		samples, err := s.SampleOnce(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, samples, 1)
+	}
+	assert.Len(t, failed, 6)
+	var ce *misc.CodeError
+	if assert.ErrorAs(t, lastErr, &ce) {
+		assert.Equal(t, -412, ce.Code)
+	}
+	assert.True(t, misc.IsRetryable(lastErr))
+
+	series := s.Series(0, "BV1")
+	assert.Len(t, series, 2)
+	assert.Equal(t, 110, series[0].View)
+	assert.Equal(t, 120, series[1].View)
+	assert.Equal(t, 12000, series[1].Online)
+	assert.Equal(t, "1.2万+", series[1].OnlineText)
+}
+
+func TestStatSamplerRateLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	s := New(nil).NewStatSampler([]StatTarget{{Aid: 1}, {Aid: 2}, {Aid: 3}}, time.Hour)
+	s.RequestInterval = 20 * time.Millisecond
+	s.stat = func(aid int, bvid string) (*StatResponse, error) {
+		return &StatResponse{}, nil
+	}
+	s.OnSample = func(StatSample) {
+		if n++; n == 3 {
+			cancel()
+		}
+	}
+	start := time.Now()
+	assert.ErrorIs(t, s.Run(ctx), context.Canceled)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	_, err := s.SampleOnce(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestStatSamplerRunZeroInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &StatSampler{
+		Targets: []StatTarget{{Aid: 1}},
+		stat: func(aid int, bvid string) (*StatResponse, error) {
+			return &StatResponse{}, nil
+		},
+		OnSample: func(StatSample) { cancel() },
+		series:   map[string][]StatSample{},
+	}
+	assert.ErrorIs(t, s.Run(ctx), context.Canceled)
+	assert.Len(t, s.Series(1, ""), 1)
+}
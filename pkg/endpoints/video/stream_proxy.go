@@ -0,0 +1,197 @@
+package video
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// 转发时需要透传给播放器的响应头
+var proxyPassHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"Content-Range",
+	"Accept-Ranges",
+	"Last-Modified",
+	"ETag",
+}
+
+// StreamProxy 本地取流代理
+//
+// 对外提供 /manifest.mpd 以及 /stream/{video|audio}/{index},
+// 请求码流时自动带上 Referer 与 User-Agent, 供无法自定义请求头的播放器使用
+type StreamProxy struct {
+	OnError func(err error) // 后台服务异常退出或码流转发中断时回调 (可选), 可能被并发调用; 关闭 listener 与播放器断开不会回调
+
+	video  *Video
+	data   *StreamData
+	videos []Stream
+	audios []Stream
+}
+
+// 创建本地取流代理
+//
+// Parameters:
+//   - data (*StreamData): Stream 返回的数据本体, 需为 DASH 格式
+//
+// 备注：
+//   - 码流地址有时效, 过期后需要重新取流并创建代理
+func (v *Video) NewStreamProxy(data *StreamData) (*StreamProxy, error) {
+	if data == nil || data.Dash == nil {
+		return nil, fmt.Errorf("stream data has no dash info")
+	}
+	videos, audios := DashStreams(data.Dash)
+	return &StreamProxy{video: v, data: data, videos: videos, audios: audios}, nil
+}
+
+// ServeHTTP 实现 http.Handler
+func (p *StreamProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// 浏览器播放器跨域访问
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Range")
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Range, Accept-Ranges")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "manifest.mpd":
+		p.serveManifest(w)
+	case strings.HasPrefix(path, "stream/"):
+		p.serveStream(w, r, strings.TrimPrefix(path, "stream/"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// 监听指定地址, 返回可交给播放器的 MPD 地址
+//
+// Parameters:
+//   - addr (string): 监听地址, 如 127.0.0.1:0 表示随机端口
+//
+// 备注：
+//   - 服务在后台运行, 调用方关闭返回的 listener 即可停止
+//   - 服务异常退出时调用 OnError
+func (p *StreamProxy) Listen(addr string) (string, net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", nil, err
+	}
+	go func() {
+		err := http.Serve(ln, p)
+		if err != nil && !errors.Is(err, net.ErrClosed) && p.OnError != nil {
+			p.OnError(err)
+		}
+	}()
+	return fmt.Sprintf("http://%s/manifest.mpd", ln.Addr().String()), ln, nil
+}
+
+func (p *StreamProxy) serveManifest(w http.ResponseWriter) {
+	// 使用相对地址, 播放器会相对 MPD 地址解析
+	mpd, err := GenerateMPD(p.data, func(kind string, index int, s Stream) string {
+		return fmt.Sprintf("stream/%s/%d", kind, index)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/dash+xml")
+	w.Write(mpd)
+}
+
+func (p *StreamProxy) serveStream(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	var streams []Stream
+	switch parts[0] {
+	case "video":
+		streams = p.videos
+	case "audio":
+		streams = p.audios
+	}
+	if index >= len(streams) {
+		http.NotFound(w, r)
+		return
+	}
+
+	s := streams[index]
+	urls := append([]string{s.URL()}, s.BackupURLs()...)
+
+	var lastErr error
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		if lastErr = p.forward(w, r, u); lastErr == nil {
+			return
+		}
+	}
+	http.Error(w, fmt.Sprintf("all upstreams failed: %v", lastErr), http.StatusBadGateway)
+}
+
+// 转发单个上游地址, 上游返回错误时不写入响应, 以便尝试备用地址
+func (p *StreamProxy) forward(w http.ResponseWriter, r *http.Request, url string) error {
+	req := p.video.client.HTTPClient.R().
+		SetContext(r.Context()).
+		SetDoNotParseResponse(true).
+		SetHeader("Referer", "https://www.bilibili.com").
+		SetHeader("User-Agent", p.video.client.UserAgent)
+	if rng := r.Header.Get("Range"); rng != "" {
+		req.SetHeader("Range", rng)
+	}
+
+	resp, err := req.Execute(r.Method, url)
+	if err != nil {
+		return err
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.IsError() {
+		return fmt.Errorf("upstream returned %s", resp.Status())
+	}
+
+	for _, h := range proxyPassHeaders {
+		if value := resp.Header().Get(h); value != "" {
+			w.Header().Set(h, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode())
+	if r.Method == http.MethodHead {
+		return nil
+	}
+	// 响应头已写出, 中断后无法再尝试备用地址; 播放器中途断开属于正常情况, 不回调
+	var writeErr error
+	_, err = io.Copy(writerFunc(func(b []byte) (int, error) {
+		n, err := w.Write(b)
+		writeErr = err
+		return n, err
+	}), body)
+	if err != nil && writeErr == nil && r.Context().Err() == nil && p.OnError != nil {
+		p.OnError(fmt.Errorf("copy stream from %s: %w", url, err))
+	}
+	return nil
+}
+
+type writerFunc func(b []byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) { return f(b) }
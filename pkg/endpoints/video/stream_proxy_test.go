@@ -0,0 +1,142 @@
+package video
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yuelioi/bilibili/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamProxy(t *testing.T) {
+	var headers []http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		switch r.URL.Path {
+		case "/expired.m4s", "/down.m4s":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case "/v.m4s":
+			w.Header().Set("Content-Type", "video/mp4")
+			w.Header().Set("Content-Range", "bytes 0-3/10")
+			w.Header().Set("X-Upstream", "hidden")
+			w.WriteHeader(http.StatusPartialContent)
+			io.WriteString(w, "vvvv")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer upstream.Close()
+
+	data := &StreamData{
+		Timelength: 10000,
+		Dash: &Dash{
+			Video: []Stream{{ID: 80, Codecid: 7, Codecs: "avc1.640032", BaseURL: upstream.URL + "/expired.m4s",
+				BackupURL: []string{upstream.URL + "/v.m4s"}}},
+			Audio: []Stream{{ID: 30280, Codecs: "mp4a.40.2", BaseURL: upstream.URL + "/down.m4s"}},
+		},
+	}
+	c := client.New()
+	c.UserAgent = "test-agent"
+	p, err := New(c).NewStreamProxy(data)
+	assert.NoError(t, err)
+
+	var serveErrs []error
+	p.OnError = func(err error) { serveErrs = append(serveErrs, err) }
+	manifest, ln, err := p.Listen("127.0.0.1:0")
+	assert.NoError(t, err)
+	base := strings.TrimSuffix(manifest, "manifest.mpd")
+
+	resp, err := http.Get(manifest)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "application/dash+xml", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), "<BaseURL>stream/video/0</BaseURL>")
+	assert.Contains(t, string(body), "<BaseURL>stream/audio/0</BaseURL>")
+
+	// 主地址失效时回退到备用地址, 透传 Range 与指定的响应头
+	req, _ := http.NewRequest(http.MethodGet, base+"stream/video/0", nil)
+	req.Header.Set("Range", "bytes=0-3")
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "vvvv", string(body))
+	assert.Equal(t, "bytes 0-3/10", resp.Header.Get("Content-Range"))
+	assert.Empty(t, resp.Header.Get("X-Upstream"))
+	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	if assert.Len(t, headers, 2) {
+		for _, h := range headers {
+			assert.Equal(t, "https://www.bilibili.com", h.Get("Referer"))
+			assert.Equal(t, "test-agent", h.Get("User-Agent"))
+			assert.Equal(t, "bytes=0-3", h.Get("Range"))
+		}
+	}
+
+	// 全部上游失败
+	resp, err = http.Get(base + "stream/audio/0")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	for _, path := range []string{"stream/video/1", "stream/video/x", "stream/other/0", "unknown"} {
+		resp, err = http.Get(base + path)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+
+	resp, err = http.Post(manifest, "text/plain", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	assert.NoError(t, ln.Close())
+	assert.Empty(t, serveErrs)
+}
+
+func TestStreamProxyCopyError(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		if r.URL.Path == "/full.m4s" {
+			w.Header().Set("Content-Length", "4")
+		}
+		io.WriteString(w, "vvvv")
+	}))
+	defer upstream.Close()
+
+	data := &StreamData{Dash: &Dash{
+		Video: []Stream{{ID: 80, BaseURL: upstream.URL + "/cut.m4s"}},
+		Audio: []Stream{{ID: 30280, BaseURL: upstream.URL + "/full.m4s"}},
+	}}
+	p, err := New(client.New()).NewStreamProxy(data)
+	assert.NoError(t, err)
+	errs := make(chan error, 2)
+	p.OnError = func(err error) { errs <- err }
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+
+	// 上游中途断开时回调
+	resp, err := http.Get(srv.URL + "/stream/video/0")
+	assert.NoError(t, err)
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	case <-time.After(time.Second):
+		t.Fatal("copy error not reported")
+	}
+
+	resp, err = http.Get(srv.URL + "/stream/audio/0")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "vvvv", string(body))
+	assert.Empty(t, errs)
+}
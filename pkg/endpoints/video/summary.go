@@ -1 +1,127 @@
 package video
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Yuelioi/bilibili/pkg/endpoints/login"
+)
+
+// AI 总结状态
+const (
+	SummaryReady       = 0  // 已生成
+	SummaryNone        = 1  // 无摘要 (未识别到语音等)
+	SummaryUnsupported = -1 // 不支持 AI 总结 (敏感内容等)
+)
+
+// 获取视频 AI 总结
+//
+// Parameters:
+//   - bvid (string): 视频的 bvid
+//   - cid (int): 视频的 cid
+//   - upMid (int): UP 主 mid
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//   - 鉴权方式：Wbi 签名
+//
+// 备注：
+//   - 需先判断 Data.Ready(), 未生成时 ModelResult 为空
+func (v *Video) Summary(bvid string, cid int, upMid int) (*SummaryResponse, error) {
+	baseURL := "https://api.bilibili.com/x/web-interface/view/conclusion/get"
+
+	params := url.Values{}
+	params.Set("bvid", bvid)
+	params.Set("cid", fmt.Sprintf("%d", cid))
+	params.Set("up_mid", fmt.Sprintf("%d", upMid))
+
+	newUrl, err := login.New(v.client).SignAndGenerateURL(baseURL + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("User-Agent", v.client.UserAgent).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&SummaryResponse{}).
+		Get(newUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*SummaryResponse), nil
+}
+
+// SummaryResponse 视频 AI 总结
+type SummaryResponse struct {
+	Code    int         `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, -403表示访问权限不足
+	Message string      `json:"message"` // 错误信息, 默认为0
+	TTL     int         `json:"ttl"`     // TTL, 固定值1
+	Data    SummaryData `json:"data"`    // 数据本体
+}
+
+// SummaryData AI 总结数据
+type SummaryData struct {
+	Code        int                `json:"code"`         // 总结状态, 见 SummaryReady 等
+	ModelResult SummaryModelResult `json:"model_result"` // 总结内容
+	Stid        string             `json:"stid"`         // 总结 id, "0" 为尚未生成, 为空则无总结
+	Status      int                `json:"status"`       // 作用尚不明确
+	LikeNum     int                `json:"like_num"`     // 点赞数
+	DislikeNum  int                `json:"dislike_num"`  // 点踩数
+}
+
+// SummaryModelResult AI 总结内容
+type SummaryModelResult struct {
+	ResultType int              `json:"result_type"` // 数据类型, 0: 没有总结, 1: 仅有摘要, 2: 有摘要及提纲
+	Summary    string           `json:"summary"`     // 视频摘要
+	Outline    []SummaryOutline `json:"outline"`     // 分段提纲
+	Subtitle   interface{}      `json:"subtitle"`    // 作用尚不明确
+}
+
+// SummaryOutline 提纲分段
+type SummaryOutline struct {
+	Title       string               `json:"title"`        // 分段标题
+	PartOutline []SummaryPartOutline `json:"part_outline"` // 分段要点
+	Timestamp   int                  `json:"timestamp"`    // 分段起始时间, 单位为秒
+}
+
+// SummaryPartOutline 分段要点
+type SummaryPartOutline struct {
+	Timestamp int    `json:"timestamp"` // 要点时间, 单位为秒
+	Content   string `json:"content"`   // 要点内容
+}
+
+// Ready 是否已生成总结
+func (d *SummaryData) Ready() bool {
+	return d.Code == SummaryReady && d.Stid != "" && d.Stid != "0" && d.ModelResult.ResultType != 0
+}
+
+// Pending 总结是否仍在生成中
+func (d *SummaryData) Pending() bool {
+	return d.Code == SummaryReady && d.Stid == "0"
+}
+
+// ViewPoints 将提纲转换为章节看点, 与 WebPlayerInfoResponse 的 ViewPoints 格式一致
+//
+// Parameters:
+//   - duration (int): 视频时长, 单位为秒, 用作最后一个章节的结束时间
+func (r *SummaryModelResult) ViewPoints(duration int) []ViewPoint {
+	points := make([]ViewPoint, 0, len(r.Outline))
+	for i, o := range r.Outline {
+		to := duration
+		if i+1 < len(r.Outline) {
+			to = r.Outline[i+1].Timestamp
+		}
+		points = append(points, ViewPoint{
+			Content: o.Title,
+			From:    o.Timestamp,
+			To:      to,
+			Type:    2,
+		})
+	}
+	return points
+}
@@ -0,0 +1,28 @@
+package video
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryViewPoints(t *testing.T) {
+	raw := `{"code":0,"stid":"123","model_result":{"result_type":2,"summary":"摘要",
+		"outline":[{"title":"开场","timestamp":0,"part_outline":[{"timestamp":5,"content":"介绍"}]},
+		{"title":"正文","timestamp":60}]}}`
+	var data SummaryData
+	assert.NoError(t, json.Unmarshal([]byte(raw), &data))
+	assert.True(t, data.Ready())
+	assert.False(t, data.Pending())
+
+	points := data.ModelResult.ViewPoints(300)
+	assert.Equal(t, []ViewPoint{
+		{Content: "开场", From: 0, To: 60, Type: 2},
+		{Content: "正文", From: 60, To: 300, Type: 2},
+	}, points)
+
+	pending := SummaryData{Stid: "0"}
+	assert.False(t, pending.Ready())
+	assert.True(t, pending.Pending())
+}
@@ -1 +1,243 @@
 package video
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// 获取视频 TAG 信息
+//
+// Parameters:
+//   - aid (int): 视频的 aid, 与 bvid 任选一个
+//   - bvid (string): 视频的 bvid, 与 aid 任选一个
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）, 登录后返回是否已点赞/点踩
+func (v *Video) Tags(aid int, bvid string) (*TagsResponse, error) {
+	baseURL := "https://api.bilibili.com/x/tag/archive/tags"
+
+	formData := map[string]string{
+		"aid":  fmt.Sprintf("%d", aid),
+		"bvid": bvid,
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&TagsResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*TagsResponse), nil
+}
+
+// 点赞视频 TAG
+//
+// Parameters:
+//   - aid (int): 视频的 aid
+//   - tagID (int): TAG id
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//
+// 备注：
+//   - 重复请求为取消点赞
+func (v *Video) LikeTag(aid int, tagID int) (*TagActionResponse, error) {
+	return v.tagAction("https://api.bilibili.com/x/tag/archive/like2", aid, tagID)
+}
+
+// 点踩视频 TAG
+//
+// Parameters:
+//   - aid (int): 视频的 aid
+//   - tagID (int): TAG id
+//
+// Authentication:
+//   - 认证方式：Cookie（SESSDATA）
+//
+// 备注：
+//   - 重复请求为取消点踩
+func (v *Video) HateTag(aid int, tagID int) (*TagActionResponse, error) {
+	return v.tagAction("https://api.bilibili.com/x/tag/archive/hate2", aid, tagID)
+}
+
+func (v *Video) tagAction(baseURL string, aid int, tagID int) (*TagActionResponse, error) {
+	formData := map[string]string{
+		"aid":    fmt.Sprintf("%d", aid),
+		"tag_id": fmt.Sprintf("%d", tagID),
+		"csrf":   v.client.CSRF,
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		SetFormData(formData).
+		SetCookie(&http.Cookie{
+			Name:  "SESSDATA",
+			Value: v.client.SESSDATA,
+		}).
+		SetResult(&TagActionResponse{}).
+		Post(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("request failed with status: %s", resp.Status())
+	}
+	return resp.Result().(*TagActionResponse), nil
+}
+
+// 获取 TAG 详细信息
+//
+// Parameters:
+//   - tagID (int): TAG id, 与 tagName 任选一个
+//   - tagName (string): TAG 名, 与 tagID 任选一个
+func (v *Video) TagInfo(tagID int, tagName string) (*TagInfoResponse, error) {
+	baseURL := "https://api.bilibili.com/x/tag/info"
+
+	formData := map[string]string{}
+	if tagID != 0 {
+		formData["tag_id"] = fmt.Sprintf("%d", tagID)
+	}
+	if tagName != "" {
+		formData["tag_name"] = tagName
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetResult(&TagInfoResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*TagInfoResponse), nil
+}
+
+// 获取 TAG 下的视频
+//
+// Parameters:
+//   - tagID (int): TAG id
+//   - pn (int): 页码, 默认为1
+//   - ps (int): 每页项数, 默认为20
+//
+// 备注：
+//   - 同时返回 TAG 信息及相似 TAG
+func (v *Video) TagVideos(tagID int, pn, ps int) (*TagVideosResponse, error) {
+	baseURL := "https://api.bilibili.com/x/tag/detail"
+
+	formData := map[string]string{
+		"tag_id": fmt.Sprintf("%d", tagID),
+	}
+	if pn != 0 {
+		formData["pn"] = fmt.Sprintf("%d", pn)
+	}
+	if ps != 0 {
+		formData["ps"] = fmt.Sprintf("%d", ps)
+	}
+
+	resp, err := v.client.HTTPClient.R().
+		SetQueryParams(formData).
+		SetResult(&TagVideosResponse{}).
+		Get(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*TagVideosResponse), nil
+}
+
+// Tag 视频 TAG 信息
+type Tag struct {
+	TagID           int      `json:"tag_id"`           // TAG id
+	TagName         string   `json:"tag_name"`         // TAG 名称
+	Cover           string   `json:"cover"`            // TAG 图片 url
+	HeadCover       string   `json:"head_cover"`       // TAG 页面头图 url
+	Content         string   `json:"content"`          // TAG 介绍
+	ShortContent    string   `json:"short_content"`    // TAG 简介
+	Type            int      `json:"type"`             // 作用尚不明确
+	State           int      `json:"state"`            // 状态, 0: 正常
+	Ctime           int      `json:"ctime"`            // 创建时间
+	Count           TagCount `json:"count"`            // 状态数
+	IsAtten         int      `json:"is_atten"`         // 是否关注, 0: 未关注, 1: 已关注
+	Likes           int      `json:"likes"`            // 作用尚不明确
+	Hates           int      `json:"hates"`            // 作用尚不明确
+	Attribute       int      `json:"attribute"`        // 作用尚不明确
+	Liked           int      `json:"liked"`            // 是否已点赞, 0: 未点赞, 1: 已点赞
+	Hated           int      `json:"hated"`            // 是否已点踩, 0: 未点踩, 1: 已点踩
+	ExtraAttr       int      `json:"extra_attr"`       // 作用尚不明确
+	MusicID         string   `json:"music_id"`         // 音乐 id, 仅 bgm 类型
+	TagType         string   `json:"tag_type"`         // TAG 类型, old_channel: 普通标签, topic: 话题, bgm: 背景音乐
+	IsActivity      bool     `json:"is_activity"`      // 是否为活动 TAG
+	Color           string   `json:"color"`            // 作用尚不明确
+	Alpha           int      `json:"alpha"`            // 作用尚不明确
+	IsSeason        bool     `json:"is_season"`        // 作用尚不明确
+	SubscribedCount int      `json:"subscribed_count"` // 关注数
+	ArchiveCount    string   `json:"archive_count"`    // 稿件数
+	FeaturedCount   int      `json:"featured_count"`   // 精选数
+	JumpURL         string   `json:"jump_url"`         // 跳转 url
+}
+
+// TagCount TAG 状态数
+type TagCount struct {
+	View  int `json:"view"`  // 作用尚不明确
+	Use   int `json:"use"`   // 被使用数
+	Atten int `json:"atten"` // 关注数
+}
+
+// TagsResponse 视频 TAG 列表
+type TagsResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    []Tag  `json:"data"`    // TAG 列表
+}
+
+// TagActionResponse 点赞/点踩 TAG
+type TagActionResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -101表示账号未登录, -111表示csrf校验失败, -400表示请求错误
+	Message string `json:"message"` // 错误信息, 如"点赞成功"/"取消成功"
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+}
+
+// TagInfoResponse TAG 详细信息
+type TagInfoResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, 16001表示TAG不存在
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    Tag    `json:"data"`    // TAG 信息
+}
+
+// TagVideosResponse TAG 下的视频
+type TagVideosResponse struct {
+	Code    int    `json:"code"`    // 返回值: 0表示成功, -400表示请求错误, 16001表示TAG不存在
+	Message string `json:"message"` // 错误信息, 默认为0
+	TTL     int    `json:"ttl"`     // TTL, 固定值1
+	Data    struct {
+		Info    Tag `json:"info"` // TAG 信息
+		Similar []struct {
+			Tid   int    `json:"tid"`   // TAG id
+			Tname string `json:"tname"` // TAG 名称
+			Cover string `json:"cover"` // TAG 图片 url
+			Atten int    `json:"atten"` // 关注数
+		} `json:"similar"` // 相似 TAG
+		News struct {
+			Count    int         `json:"count"`    // 视频总数
+			Archives []VideoData `json:"archives"` // 视频列表
+		} `json:"news"` // 最新视频
+	} `json:"data"` // 数据本体
+}
+
+// TagNames 返回 TAG 名称列表
+func (r *TagsResponse) TagNames() []string {
+	names := make([]string, 0, len(r.Data))
+	for _, t := range r.Data {
+		names = append(names, t.TagName)
+	}
+	return names
+}
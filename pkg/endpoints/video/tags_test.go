@@ -0,0 +1,31 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/Yuelioi/bilibili/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	tc := tests.NewTestClient().WithSessdata()
+	service := New(tc.Client)
+
+	resp, err := service.Tags(aid, "")
+	t.Logf("Response: %+v", resp)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, resp.Code, 0)
+}
+
+func TestTagVideos(t *testing.T) {
+	tc := tests.NewTestClient()
+	service := New(tc.Client)
+
+	resp, err := service.TagVideos(4227, 1, 20)
+	t.Logf("Response: %+v", resp)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, resp.Code, 0)
+}
@@ -0,0 +1,242 @@
+package misc
+
+import "context"
+
+// PageFunc 获取第 pn 页 (从1开始), 每页 ps 项, 返回本页列表及总数 (未知时为0)
+type PageFunc[T any] func(pn, ps int) (items []T, total int, err error)
+
+// Pager 分页游标, 按页请求并逐项返回, 直到取完
+//
+// 用法:
+//
+//	p := misc.NewPager(ctx, 20, 20, 0, fetch)
+//	for p.Next() {
+//		item := p.Item()
+//	}
+//	if err := p.Err(); err != nil { ... }
+//
+// 以下任一情况结束遍历:
+//   - 返回空页
+//   - 已取得的项数达到总数
+//   - 总数未知且本页不足 ps 项
+//   - 达到 maxItems
+//   - ctx 结束或请求出错, 此时 Err 返回对应错误
+type Pager[T any] struct {
+	ctx      context.Context
+	fetch    PageFunc[T]
+	pageSize int
+	maxItems int
+
+	pn      int
+	fetched int // 接口已返回的项数
+	count   int // 已通过 Next 返回的项数
+	buf     []T
+	cur     T
+	done    bool
+	err     error
+}
+
+// NewPager 创建分页游标
+//
+// Parameters:
+//   - pageSize (int): 每页项数, 为0或超过 maxPageSize 时取 maxPageSize
+//   - maxPageSize (int): 接口允许的最大每页项数
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func NewPager[T any](ctx context.Context, pageSize, maxPageSize, maxItems int, fetch PageFunc[T]) *Pager[T] {
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return &Pager[T]{
+		ctx:      ctx,
+		fetch:    fetch,
+		pageSize: pageSize,
+		maxItems: maxItems,
+	}
+}
+
+// Next 前进到下一项, 没有更多项或出错时返回 false
+func (p *Pager[T]) Next() bool {
+	if p.maxItems > 0 && p.count >= p.maxItems {
+		return false
+	}
+	for len(p.buf) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		p.pn++
+		items, total, err := p.fetch(p.pn, p.pageSize)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.fetched += len(items)
+		if len(items) == 0 ||
+			(total > 0 && p.fetched >= total) ||
+			(total <= 0 && len(items) < p.pageSize) {
+			p.done = true
+		}
+		p.buf = items
+	}
+
+	p.cur = p.buf[0]
+	p.buf = p.buf[1:]
+	p.count++
+	return true
+}
+
+// Item 返回当前项
+func (p *Pager[T]) Item() T {
+	return p.cur
+}
+
+// Err 返回遍历中遇到的错误
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Page 返回最近请求的页码
+func (p *Pager[T]) Page() int {
+	return p.pn
+}
+
+// All 取出剩余全部项
+func (p *Pager[T]) All() ([]T, error) {
+	var items []T
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+	return items, p.Err()
+}
+
+// CursorFunc 以游标 cursor 请求一页 (首页为空), 返回本页列表、下一页游标及是否已到末尾
+type CursorFunc[T any] func(cursor string) (items []T, next string, end bool, err error)
+
+// CursorPager 游标分页, 用于以 offset 翻页的接口, 用法同 Pager
+//
+// 以下任一情况结束遍历:
+//   - 接口表示已到末尾, 或返回空页, 或下一页游标为空
+//   - 达到 maxItems
+//   - ctx 结束或请求出错, 此时 Err 返回对应错误
+//
+// 中断后继续遍历:
+//
+//	cursor, offset := p.Cursor(), p.Offset()
+//	// ...
+//	p = misc.NewCursorPager(ctx, cursor, 0, fetch).Skip(offset)
+type CursorPager[T any] struct {
+	ctx      context.Context
+	fetch    CursorFunc[T]
+	maxItems int
+
+	cursor string // 正在读取的页的游标, 该页读完后为下一页的游标
+	offset int    // 该页已返回的项数
+	next   string // 下一次请求使用的游标
+	skip   int    // 首页需要跳过的项数
+	count  int
+	buf    []T
+	cur    T
+	done   bool
+	err    error
+}
+
+// NewCursorPager 创建游标分页
+//
+// Parameters:
+//   - cursor (string): 起始游标, 为空时从第一页开始, 可传入之前保存的 Cursor 以继续遍历
+//   - maxItems (int): 最多返回的项数, 0 表示不限制
+func NewCursorPager[T any](ctx context.Context, cursor string, maxItems int, fetch CursorFunc[T]) *CursorPager[T] {
+	return &CursorPager[T]{
+		ctx:      ctx,
+		fetch:    fetch,
+		maxItems: maxItems,
+		cursor:   cursor,
+		next:     cursor,
+	}
+}
+
+// Skip 跳过起始页的前 n 项, 与之前保存的 Offset 配合使用
+func (p *CursorPager[T]) Skip(n int) *CursorPager[T] {
+	p.skip = n
+	return p
+}
+
+// Next 前进到下一项, 没有更多项或出错时返回 false
+func (p *CursorPager[T]) Next() bool {
+	if p.maxItems > 0 && p.count >= p.maxItems {
+		return false
+	}
+	for len(p.buf) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		cursor := p.next
+		items, next, end, err := p.fetch(cursor)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		if end || len(items) == 0 || next == "" {
+			p.done = true
+		}
+		p.cursor, p.offset, p.next = cursor, 0, next
+		if p.skip > 0 {
+			n := p.skip
+			if n > len(items) {
+				n = len(items)
+			}
+			items, p.offset, p.skip = items[n:], n, 0
+		}
+		p.buf = items
+		if len(p.buf) == 0 {
+			p.cursor, p.offset = next, 0
+		}
+	}
+
+	p.cur = p.buf[0]
+	p.buf = p.buf[1:]
+	p.offset++
+	p.count++
+	if len(p.buf) == 0 {
+		p.cursor, p.offset = p.next, 0
+	}
+	return true
+}
+
+// Item 返回当前项
+func (p *CursorPager[T]) Item() T {
+	return p.cur
+}
+
+// Err 返回遍历中遇到的错误
+func (p *CursorPager[T]) Err() error {
+	return p.err
+}
+
+// Cursor 返回继续遍历时使用的游标, 当前页未读完时为当前页的游标, 需配合 Offset 跳过已返回的项
+func (p *CursorPager[T]) Cursor() string {
+	return p.cursor
+}
+
+// Offset 返回 Cursor 所指的页中已返回的项数
+func (p *CursorPager[T]) Offset() int {
+	return p.offset
+}
+
+// All 取出剩余全部项
+func (p *CursorPager[T]) All() ([]T, error) {
+	var items []T
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+	return items, p.Err()
+}
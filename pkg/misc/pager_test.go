@@ -0,0 +1,120 @@
+package misc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 共 n 项, 每项为其序号
+func pages(n int, calls *[]int) PageFunc[int] {
+	return func(pn, ps int) ([]int, int, error) {
+		*calls = append(*calls, ps)
+		var items []int
+		for i := (pn - 1) * ps; i < pn*ps && i < n; i++ {
+			items = append(items, i)
+		}
+		return items, n, nil
+	}
+}
+
+func TestPager(t *testing.T) {
+	var calls []int
+	items, err := NewPager(context.Background(), 50, 20, 0, pages(45, &calls)).All()
+	assert.NoError(t, err)
+	assert.Len(t, items, 45)
+	assert.Equal(t, 44, items[44])
+	assert.Equal(t, []int{20, 20, 20}, calls) // 每页不超过上限, 取满总数后停止
+
+	calls = nil
+	items, err = NewPager(context.Background(), 10, 20, 15, pages(45, &calls)).All()
+	assert.NoError(t, err)
+	assert.Len(t, items, 15)
+	assert.Len(t, calls, 2)
+}
+
+func TestPagerUnknownTotal(t *testing.T) {
+	n := 0
+	p := NewPager(context.Background(), 3, 20, 0, func(pn, ps int) ([]string, int, error) {
+		n++
+		if pn == 1 {
+			return []string{"a", "b", "c"}, 0, nil
+		}
+		return []string{"d"}, 0, nil
+	})
+	items, err := p.All()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, items)
+	assert.Equal(t, 2, n) // 不足一页即结束
+
+	p = NewPager(context.Background(), 3, 20, 0, func(pn, ps int) ([]string, int, error) {
+		return nil, 0, nil
+	})
+	assert.False(t, p.Next())
+	assert.NoError(t, p.Err())
+}
+
+func TestPagerError(t *testing.T) {
+	boom := errors.New("boom")
+	p := NewPager(context.Background(), 1, 20, 0, func(pn, ps int) ([]int, int, error) {
+		if pn == 2 {
+			return nil, 0, boom
+		}
+		return []int{pn}, 0, nil
+	})
+	items, err := p.All()
+	assert.Equal(t, []int{1}, items)
+	assert.ErrorIs(t, err, boom)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p = NewPager(ctx, 1, 20, 0, func(pn, ps int) ([]int, int, error) { return []int{1}, 0, nil })
+	assert.False(t, p.Next())
+	assert.ErrorIs(t, p.Err(), context.Canceled)
+}
+
+func TestCursorPager(t *testing.T) {
+	// 游标为下一项的序号
+	var cursors []string
+	fetch := func(cursor string) ([]int, string, bool, error) {
+		cursors = append(cursors, cursor)
+		start := 0
+		if cursor != "" {
+			start = int(cursor[0] - '0')
+		}
+		var items []int
+		for i := start; i < start+3 && i < 7; i++ {
+			items = append(items, i)
+		}
+		next := string(rune('0' + start + 3))
+		return items, next, start+3 >= 7, nil
+	}
+
+	items, err := NewCursorPager(context.Background(), "", 0, fetch).All()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, items)
+	assert.Equal(t, []string{"", "3", "6"}, cursors)
+
+	cursors = nil
+	p := NewCursorPager(context.Background(), "3", 2, fetch)
+	items, err = p.All()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, items)
+	assert.Equal(t, "3", p.Cursor())
+	assert.Equal(t, 2, p.Offset())
+
+	// 从保存的位置继续, 不丢失当前页未读的项
+	p = NewCursorPager(context.Background(), p.Cursor(), 2, fetch).Skip(p.Offset())
+	items, err = p.All()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5, 6}, items)
+	assert.Equal(t, "9", p.Cursor())
+	assert.Equal(t, 0, p.Offset())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewCursorPager(ctx, "", 0, fetch).All()
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,106 @@
+package misc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// 风控及限流相关的返回值
+var riskCodes = map[int]bool{
+	-352: true, // 风控校验失败
+	-412: true, // 请求被拦截
+	-509: true, // 请求过于频繁
+	-799: true, // 请求过于频繁, 请稍后再试
+}
+
+// CodeError 接口返回非0返回值
+type CodeError struct {
+	Code    int
+	Message string
+}
+
+func (e *CodeError) Error() string {
+	return fmt.Sprintf("bilibili api error: %d %s", e.Code, e.Message)
+}
+
+// IsRisk 是否为风控/限流返回值
+func (e *CodeError) IsRisk() bool {
+	return riskCodes[e.Code]
+}
+
+// IsRetryable 判断错误是否值得重试: 风控/限流返回值, resty 限流器拒绝, 网络超时
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var ce *CodeError
+	if errors.As(err, &ce) {
+		return ce.IsRisk()
+	}
+	if errors.Is(err, resty.ErrRateLimitExceeded) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// Retry 执行 fn, 可重试的错误按指数退避重试
+//
+// Parameters:
+//   - attempts (int): 最多执行次数, 至少为1
+//   - backoff (time.Duration): 首次重试前的等待时间, 之后每次翻倍
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	var err error
+	for i := 0; i < attempts || i == 0; i++ {
+		if i > 0 {
+			if werr := sleep(ctx, backoff<<(i-1)); werr != nil {
+				return werr
+			}
+		}
+		if err = fn(); err == nil || !IsRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// Throttle 保证两次请求之间至少间隔 Interval
+type Throttle struct {
+	Interval time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Wait 等待到下一次允许请求的时间
+func (t *Throttle) Wait(ctx context.Context) error {
+	t.mu.Lock()
+	next := t.last.Add(t.Interval)
+	if now := time.Now(); next.Before(now) {
+		next = now
+	}
+	t.last = next
+	t.mu.Unlock()
+
+	return sleep(ctx, time.Until(next))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
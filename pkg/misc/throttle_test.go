@@ -0,0 +1,45 @@
+package misc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return &CodeError{Code: -352, Message: "风控校验失败"}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// 非风控错误不重试
+	calls = 0
+	err = Retry(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		return &CodeError{Code: -404}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	assert.True(t, IsRetryable(resty.ErrRateLimitExceeded))
+	assert.False(t, IsRetryable(errors.New("boom")))
+}
+
+func TestThrottle(t *testing.T) {
+	th := &Throttle{Interval: 10 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, th.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Yuelioi/bilibili/pkg/client"
+	"github.com/go-resty/resty/v2"
+)
+
+// RecordTransport 记录请求并返回固定响应的 RoundTripper, 用于离线检查请求编码
+type RecordTransport struct {
+	Requests []*http.Request
+	Forms    []url.Values // 各请求的表单, 无请求体时为查询参数
+	Bodies   [][]byte     // 各请求的原始请求体, 用于检查 JSON 请求
+	Body     string       // 响应内容, 默认为 {"code":0,"message":"0","ttl":1}
+}
+
+func (rt *RecordTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	form := r.URL.Query()
+	var b []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		if b, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		if !strings.Contains(r.Header.Get("Content-Type"), "json") {
+			if form, err = url.ParseQuery(string(b)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	rt.Requests = append(rt.Requests, r)
+	rt.Forms = append(rt.Forms, form)
+	rt.Bodies = append(rt.Bodies, b)
+
+	body := rt.Body
+	if body == "" {
+		body = `{"code":0,"message":"0","ttl":1}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+// 创建请求经由 RecordTransport 的客户端, SESSDATA 为 "sess", CSRF 为 "token"
+func NewRecordClient() (*client.Client, *RecordTransport) {
+	rt := &RecordTransport{}
+	return &client.Client{
+		HTTPClient: resty.New().SetTransport(rt),
+		SESSDATA:   "sess",
+		CSRF:       "token",
+	}, rt
+}